@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 
 	pb "github.com/afshin-deriv/c-learning/proto"
@@ -30,9 +32,10 @@ func (c *CLI) initLesson(lessonID int32) error {
 	ctx := context.Background()
 	lesson, err := c.client.GetLesson(ctx, &pb.LessonRequest{
 		LessonId: lessonID,
+		UserId:   c.config.UserID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to get lesson: %v", err)
+		return fmt.Errorf("failed to get lesson: %w", err)
 	}
 
 	// Create lesson directory
@@ -56,6 +59,14 @@ int main() {
 		}
 	}
 
+	// Provided files are replaced on every init, and kept read-only as the
+	// server ignores any changes to them
+	for _, f := range lesson.ProvidedFiles {
+		if err := writeProvidedFile(lessonDir, f); err != nil {
+			return err
+		}
+	}
+
 	// Create or update lesson info
 	infoContent := fmt.Sprintf(`=== Lesson %d: %s ===
 
@@ -74,6 +85,32 @@ To complete this lesson:
 3. Once all tests pass, you can proceed to the next lesson
 `, lesson.LessonId, lesson.Title, lesson.Description,
 		formatObjectives(lesson.LearningObjectives), lesson.ExampleCode)
+	if lesson.HiddenTestCount > 0 {
+		infoContent += fmt.Sprintf("\nThis lesson also has %d hidden tests, which only report whether they passed.\n", lesson.HiddenTestCount)
+	}
+	if lesson.PassThreshold > 0 && lesson.PassThreshold < 100 {
+		infoContent += fmt.Sprintf("You need a score of %.0f%% to complete it.\n", lesson.PassThreshold)
+	}
+	if lesson.HintCount > 0 {
+		infoContent += fmt.Sprintf("\nStuck? 'cli hint' reveals the next of %d hints", lesson.HintCount)
+		if lesson.HintPenalty > 0 {
+			infoContent += fmt.Sprintf(", each costing %.0f%% of your score", lesson.HintPenalty)
+		}
+		infoContent += ".\n"
+	}
+	if lesson.HasSolution {
+		infoContent += "A reference solution unlocks with 'cli solution' once you complete the lesson"
+		if lesson.SolutionAfterFailures > 0 {
+			infoContent += fmt.Sprintf(", or after %d failed attempts", lesson.SolutionAfterFailures)
+		}
+		infoContent += ".\n"
+	}
+	if len(lesson.ProvidedFiles) > 0 {
+		infoContent += "\nProvided Files (read-only, compiled with your solution):\n"
+		for _, f := range lesson.ProvidedFiles {
+			infoContent += "- " + f.Path + "\n"
+		}
+	}
 
 	if err := os.WriteFile(filepath.Join(lessonDir, "README.md"), []byte(infoContent), 0644); err != nil {
 		return fmt.Errorf("failed to create lesson info: %v", err)
@@ -82,17 +119,7 @@ To complete this lesson:
 	// Create Makefile if it doesn't exist
 	makefilePath := filepath.Join(lessonDir, "Makefile")
 	if _, err := os.Stat(makefilePath); os.IsNotExist(err) {
-		makefile := `CC=gcc
-CFLAGS=-Wall -Wextra
-
-solution: solution.c
-	$(CC) $(CFLAGS) -o $@ $<
-
-.PHONY: clean
-clean:
-	rm -f solution *.o *~
-`
-		if err := os.WriteFile(makefilePath, []byte(makefile), 0644); err != nil {
+		if err := os.WriteFile(makefilePath, []byte(makefile(lesson.Build)), 0644); err != nil {
 			return fmt.Errorf("failed to create Makefile: %v", err)
 		}
 	}
@@ -110,8 +137,70 @@ clean:
 	return nil
 }
 
+// writeProvidedFile writes one of a lesson's provided files into the
+// workspace, read-only
+func writeProvidedFile(lessonDir string, f *pb.SourceFile) error {
+	path := filepath.Join(lessonDir, filepath.FromSlash(f.Path))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %v", f.Path, err)
+	}
+	// The old copy is read-only too
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %v", f.Path, err)
+	}
+	if err := os.WriteFile(path, []byte(f.Content), 0444); err != nil {
+		return fmt.Errorf("failed to write %s: %v", f.Path, err)
+	}
+	return nil
+}
+
+// makefile builds every .c file in the workspace but the reference
+// solution with the same compiler, standard, flags, defines and libraries
+// the server grades with
+func makefile(build *pb.BuildConfig) string {
+	if build == nil {
+		build = &pb.BuildConfig{Compiler: "gcc", Flags: []string{"-Wall", "-Werror"}}
+	}
+
+	var cflags []string
+	if build.Std != "" {
+		cflags = append(cflags, "-std="+build.Std)
+	}
+	cflags = append(cflags, build.Flags...)
+	for _, define := range build.Defines {
+		cflags = append(cflags, "-D"+define)
+	}
+
+	return fmt.Sprintf(`CC=%s
+CFLAGS=%s
+LDLIBS=%s
+
+SRCS=$(shell find . -name '*.c' -not -path '*/.*' -not -path ./%s)
+HDRS=$(shell find . -name '*.h' -not -path '*/.*')
+
+solution: $(SRCS) $(HDRS)
+	$(CC) $(CFLAGS) -o $@ $(SRCS) $(LDLIBS)
+
+.PHONY: clean
+clean:
+	rm -f solution *.o *~
+`, build.Compiler, shellWords(cflags), shellWords(build.Libs), referenceFile)
+}
+
+// shellWords joins words for a shell command line, quoting where needed
+func shellWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if w == "" || strings.ContainsAny(w, " \t'\"\\$`*?[]{}()<>|&;#~!") {
+			w = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+		}
+		quoted[i] = strings.ReplaceAll(w, "$", "$$")
+	}
+	return strings.Join(quoted, " ")
+}
+
 // test runs the tests for the current lesson
-func (c *CLI) test() error {
+func (c *CLI) test(sanitizers []string, memoryChecker string) error {
 	// Check if we're in a lesson directory
 	currentDir := c.config.CurrentDir
 	if currentDir == "" {
@@ -119,61 +208,340 @@ func (c *CLI) test() error {
 	}
 
 	// Read the current solution
-	solutionPath := filepath.Join(currentDir, "solution.c")
-	code, err := os.ReadFile(solutionPath)
+	sources, err := readSources(currentDir)
 	if err != nil {
-		return fmt.Errorf("failed to read solution: %v", err)
+		return err
+	}
+	if _, ok := sources["solution.c"]; !ok {
+		return fmt.Errorf("failed to read solution: solution.c not found in %s", currentDir)
+	}
+	var files []*pb.SourceFile
+	for _, path := range sortedPaths(sources) {
+		files = append(files, &pb.SourceFile{Path: path, Content: sources[path]})
 	}
 
-	// Run tests
+	// Run tests, printing each step as the server reports it
 	ctx := context.Background()
-	result, err := c.client.ValidateCode(ctx, &pb.CodeSubmission{
-		LessonId: c.config.LastLesson,
-		Code:     string(code),
+	stream, err := c.client.ValidateCodeStream(ctx, &pb.CodeSubmission{
+		LessonId:      c.config.LastLesson,
+		Files:         files,
+		UserId:        c.config.UserID,
+		Sanitizers:    sanitizers,
+		MemoryChecker: memoryChecker,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to validate code: %v", err)
+		return fmt.Errorf("failed to validate code: %w", err)
 	}
 
-	// Display results
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "\n=== Test Results for Lesson %d ===\n\n", c.config.LastLesson)
+	fmt.Printf("\n=== Test Results for Lesson %d ===\n\n", c.config.LastLesson)
 
-	allPassed := true
-	for _, test := range result.TestResults {
-		if !test.Passed {
-			allPassed = false
+	var result *pb.ValidationResponse
+	compileFailed := false
+	queued := false
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
 		}
-		status := "✓"
-		if !test.Passed {
-			status = "✗"
+		if err != nil {
+			return fmt.Errorf("failed to validate code: %w", err)
 		}
-		fmt.Fprintf(w, "%s\t%s\n", status, test.TestCaseDescription)
-		if !test.Passed {
-			fmt.Fprintf(w, "\tExpected: %s\n", test.ExpectedOutput)
-			fmt.Fprintf(w, "\tGot: %s\n", test.ActualOutput)
+
+		switch e := event.Event.(type) {
+		case *pb.ValidationEvent_Queued:
+			fmt.Printf("\rWaiting for a free grader (position %d in queue)... ", e.Queued.Position)
+			queued = true
+		case *pb.ValidationEvent_CompileStarted:
+			if queued {
+				fmt.Println()
+			}
+			fmt.Print("Compiling... ")
+		case *pb.ValidationEvent_CompileFinished:
+			compileFailed = !e.CompileFinished.Success
+			if compileFailed {
+				fmt.Println("failed")
+				for _, command := range e.CompileFinished.Commands {
+					fmt.Printf("$ %s\n", command)
+				}
+			} else if e.CompileFinished.Cached {
+				fmt.Println("done (cached)")
+			} else {
+				fmt.Println("done")
+			}
+			if len(e.CompileFinished.Diagnostics) > 0 {
+				fmt.Print(renderDiagnostics(sources, e.CompileFinished.Diagnostics))
+			} else if output := strings.TrimSpace(e.CompileFinished.Output); output != "" {
+				fmt.Println(output)
+			}
+			fmt.Println()
+		case *pb.ValidationEvent_TestStarted:
+			fmt.Printf("[%d/%d] %s ... ", e.TestStarted.Index+1, e.TestStarted.Total, e.TestStarted.Description)
+		case *pb.ValidationEvent_TestFinished:
+			printTestResult(e.TestFinished.Result)
+		case *pb.ValidationEvent_Summary:
+			result = e.Summary
 		}
 	}
-	w.Flush()
+	if result == nil {
+		return fmt.Errorf("server ended validation without a result")
+	}
 
-	if allPassed {
+	if result.MaxPoints > 0 {
+		fmt.Printf("\nScore: %.0f%% (%d/%d points, %.0f%% needed to pass",
+			result.Score, result.Points, result.MaxPoints, result.PassThreshold)
+		if result.HintDeduction > 0 {
+			fmt.Printf("; %.0f%% off for %s", result.HintDeduction, plural(int(result.HintsUsed), "hint"))
+		}
+		fmt.Println(")")
+	}
+
+	if result.IsValid {
 		fmt.Printf("\n🎉 Congratulations! All tests passed for Lesson %d!\n", c.config.LastLesson)
 		fmt.Println("You can now proceed to the next lesson with 'cli next'")
 		return nil
 	}
+	if result.CanProceed {
+		fmt.Printf("\n✓ You passed Lesson %d, though some tests still fail.\n", c.config.LastLesson)
+		fmt.Println("Keep improving your solution, or proceed to the next lesson with 'cli next'")
+		return nil
+	}
 
+	if compileFailed || result.CompileError {
+		fmt.Println("Fix the compiler errors above and run 'cli test' again.")
+		return &exitCodeError{code: exitCompileError}
+	}
+	if len(result.TestResults) == 0 {
+		fmt.Printf("\n%s\n", result.Feedback)
+		return &exitCodeError{code: exitTestsFailed}
+	}
 	fmt.Println("\nSome tests failed. Keep working on your solution!")
-	return nil
+	return &exitCodeError{code: exitTestsFailed}
 }
 
-// next moves to the next lesson
+// printTestResult finishes the line of a running test with its outcome
+func printTestResult(test *pb.TestResult) {
+	if test.Passed {
+		fmt.Println("✓")
+		printSanitizerFindings(test.SanitizerFindings)
+		return
+	}
+
+	if test.Hidden {
+		fmt.Println("✗ (hidden test)")
+	} else {
+		fmt.Println("✗")
+	}
+	if reason := failureReason(test); reason != "" {
+		fmt.Printf("      Reason: %s\n", reason)
+	}
+	// Hidden tests only report how many assertions failed
+	if !test.Hidden {
+		for _, a := range test.Assertions {
+			if !a.Passed && a.Message != "" {
+				fmt.Printf("      ✗ %s (line %d): %s\n", a.Test, a.Line, a.Message)
+			} else if !a.Passed {
+				fmt.Printf("      ✗ %s (line %d)\n", a.Test, a.Line)
+			}
+		}
+	}
+	// The server leaves out the output of hidden tests
+	if !test.Hidden && (len(test.Assertions) == 0 || test.ExpectedOutput != "") {
+		fmt.Printf("      Expected: %s\n", test.ExpectedOutput)
+		fmt.Printf("      Got: %s\n", test.ActualOutput)
+	}
+	if test.ActualStderr != "" {
+		fmt.Printf("      Stderr: %s\n", test.ActualStderr)
+	}
+	printSanitizerFindings(test.SanitizerFindings)
+}
+
+// printSanitizerFindings lists what the sanitizers reported during a test
+func printSanitizerFindings(findings []*pb.SanitizerFinding) {
+	for _, f := range findings {
+		fmt.Printf("      ⚠ %s: %s\n", checkerName(f.Sanitizer), f.Message)
+		if f.File != "" {
+			fmt.Printf("        at %s:%d", f.File, f.Line)
+			if f.Function != "" {
+				fmt.Printf(" in %s", f.Function)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func checkerName(sanitizer string) string {
+	switch sanitizer {
+	case "address":
+		return "AddressSanitizer"
+	case "undefined":
+		return "UndefinedBehaviorSanitizer"
+	case "leak":
+		return "LeakSanitizer"
+	default:
+		return sanitizer
+	}
+}
+
+// next moves to the next lesson the server says is open to the user
 func (c *CLI) next() error {
+	ctx := context.Background()
+	next, err := c.client.GetNextLesson(ctx, &pb.NextLessonRequest{
+		UserId: c.config.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find the next lesson: %w", err)
+	}
+	if next.CurriculumCompleted {
+		fmt.Println("🎓 You have completed every lesson. Well done!")
+		return nil
+	}
+	return c.initLesson(next.LessonId)
+}
+
+// hint reveals the next hint for the current lesson
+func (c *CLI) hint() error {
 	if c.config.CurrentDir == "" {
 		return fmt.Errorf("no active lesson. Run 'cli lesson --id <number>' first")
 	}
 
-	nextLessonID := c.config.LastLesson + 1
-	return c.initLesson(nextLessonID)
+	ctx := context.Background()
+	resp, err := c.client.GetHint(ctx, &pb.HintRequest{
+		LessonId: c.config.LastLesson,
+		UserId:   c.config.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get a hint: %w", err)
+	}
+
+	switch {
+	case resp.TotalHints == 0:
+		fmt.Printf("Lesson %d has no hints.\n", c.config.LastLesson)
+	case resp.Revealed:
+		n := len(resp.Hints)
+		fmt.Printf("💡 Hint %d of %d for Lesson %d:\n\n%s\n", n, resp.TotalHints, c.config.LastLesson, resp.Hints[n-1])
+		if remaining := int(resp.TotalHints) - n; remaining > 0 {
+			fmt.Printf("\n%s left; run 'cli hint' again if you need more.\n", plural(remaining, "hint"))
+		}
+		if resp.HintPenalty > 0 {
+			fmt.Printf("Your score for this lesson is now reduced by %.0f%%.\n", resp.HintPenalty*float32(n))
+		}
+	default:
+		fmt.Printf("You have seen all %d hints for Lesson %d:\n", resp.TotalHints, c.config.LastLesson)
+		for i, h := range resp.Hints {
+			fmt.Printf("\n💡 %d. %s\n", i+1, h)
+		}
+	}
+	return nil
+}
+
+// solution fetches the current lesson's reference solution, once the server
+// reveals it, writes it next to solution.c and shows how the two differ
+func (c *CLI) solution() error {
+	currentDir := c.config.CurrentDir
+	if currentDir == "" {
+		return fmt.Errorf("no active lesson. Run 'cli lesson --id <number>' first")
+	}
+
+	ctx := context.Background()
+	resp, err := c.client.GetSolution(ctx, &pb.SolutionRequest{
+		LessonId: c.config.LastLesson,
+		UserId:   c.config.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get the reference solution: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(currentDir, referenceFile), []byte(resp.Code), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", referenceFile, err)
+	}
+	fmt.Printf("Wrote the reference solution for Lesson %d to %s\n", c.config.LastLesson, referenceFile)
+	if !resp.Completed {
+		fmt.Printf("It was unlocked after %s. Read it, then try writing your own version without it.\n",
+			plural(int(resp.FailedAttempts), "failed attempt"))
+	}
+
+	mine, err := os.ReadFile(filepath.Join(currentDir, "solution.c"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read solution: %v", err)
+	}
+	diff := unifiedDiff("solution.c", referenceFile, string(mine), resp.Code)
+	if diff == "" {
+		fmt.Println("\nYour solution.c is identical to it.")
+		return nil
+	}
+	fmt.Printf("\nHow your solution.c differs from it:\n\n%s", diff)
+	return nil
+}
+
+// plural formats a count of things, e.g. "1 hint" or "3 hints"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// failureReason explains why a test failed
+func failureReason(test *pb.TestResult) string {
+	if test.Hidden {
+		return hiddenFailureReason(test)
+	}
+	switch test.Status {
+	case pb.TestStatus_TEST_STATUS_WRONG_ANSWER:
+		if failed := failedAssertions(test); failed > 0 {
+			return fmt.Sprintf("%d of %d assertions failed", failed, len(test.Assertions))
+		}
+		return test.Mismatch
+	case pb.TestStatus_TEST_STATUS_RUNTIME_ERROR:
+		return fmt.Sprintf("program exited with status %d", test.ExitCode)
+	case pb.TestStatus_TEST_STATUS_TIME_LIMIT_EXCEEDED:
+		return fmt.Sprintf("time limit exceeded after %dms", test.DurationMs)
+	case pb.TestStatus_TEST_STATUS_MEMORY_LIMIT_EXCEEDED:
+		return "memory limit exceeded"
+	case pb.TestStatus_TEST_STATUS_KILLED_BY_SIGNAL:
+		return fmt.Sprintf("program was killed by %s", test.Signal)
+	case pb.TestStatus_TEST_STATUS_SANITIZER_ERROR:
+		return "sanitizer found " + test.Mismatch
+	case pb.TestStatus_TEST_STATUS_OUTPUT_LIMIT_EXCEEDED:
+		return "program produced too much output"
+	default:
+		return ""
+	}
+}
+
+// hiddenFailureReason describes how a hidden test failed, for which the
+// server reports no details
+func hiddenFailureReason(test *pb.TestResult) string {
+	switch test.Status {
+	case pb.TestStatus_TEST_STATUS_WRONG_ANSWER:
+		if failed := failedAssertions(test); failed > 0 {
+			return fmt.Sprintf("%d of %d assertions failed", failed, len(test.Assertions))
+		}
+		return test.Mismatch
+	case pb.TestStatus_TEST_STATUS_RUNTIME_ERROR:
+		return "program exited with an error"
+	case pb.TestStatus_TEST_STATUS_TIME_LIMIT_EXCEEDED:
+		return "time limit exceeded"
+	case pb.TestStatus_TEST_STATUS_KILLED_BY_SIGNAL:
+		return "program was killed by a signal"
+	case pb.TestStatus_TEST_STATUS_SANITIZER_ERROR:
+		return "sanitizer found a problem"
+	default:
+		return failureReason(&pb.TestResult{Status: test.Status})
+	}
+}
+
+func failedAssertions(test *pb.TestResult) int {
+	failed := 0
+	for _, a := range test.Assertions {
+		if !a.Passed {
+			failed++
+		}
+	}
+	return failed
 }
 
 func formatObjectives(objectives []string) string {
@@ -200,6 +568,54 @@ func (c *CLI) showProgress() error {
 	return nil
 }
 
+// listLessons shows every lesson grouped by track with the user's status
+func (c *CLI) listLessons() error {
+	ctx := context.Background()
+	resp, err := c.client.ListLessons(ctx, &pb.ListLessonsRequest{
+		UserId: c.config.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list lessons: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, track := range resp.Tracks {
+		fmt.Fprintf(w, "\n=== %s ===\n", track.Name)
+		for _, lesson := range track.Lessons {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", statusIcon(lesson.Status), lesson.LessonId,
+				lesson.Title, formatPrerequisites(lesson.Prerequisites))
+		}
+	}
+	w.Flush()
+
+	fmt.Println("\nRun 'cli lesson --id <number>' to start a lesson")
+	return nil
+}
+
+func statusIcon(status pb.LessonStatus) string {
+	switch status {
+	case pb.LessonStatus_LESSON_STATUS_COMPLETED:
+		return "✓"
+	case pb.LessonStatus_LESSON_STATUS_AVAILABLE:
+		return "→"
+	case pb.LessonStatus_LESSON_STATUS_LOCKED:
+		return "·"
+	default:
+		return " "
+	}
+}
+
+func formatPrerequisites(prereqs []int32) string {
+	if len(prereqs) == 0 {
+		return ""
+	}
+	ids := make([]string, len(prereqs))
+	for i, id := range prereqs {
+		ids[i] = fmt.Sprint(id)
+	}
+	return "(requires " + strings.Join(ids, ", ") + ")"
+}
+
 func (c *CLI) initWorkspace() error {
 	if err := os.MkdirAll(c.config.WorkingDir, 0755); err != nil {
 		return fmt.Errorf("failed to create workspace directory: %v", err)
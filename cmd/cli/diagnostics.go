@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// renderDiagnostics formats compiler diagnostics like gcc does, quoting the
+// offending line of the student's sources, by path, with a caret under the
+// column
+func renderDiagnostics(sources map[string]string, diags []*pb.Diagnostic) string {
+	var b strings.Builder
+	for _, d := range diags {
+		if d.File != "" {
+			fmt.Fprintf(&b, "%s:%d:%d: ", d.File, d.Line, d.Column)
+		}
+		fmt.Fprintf(&b, "%s: %s", severityName(d.Severity), d.Message)
+		if d.Option != "" {
+			fmt.Fprintf(&b, " [%s]", d.Option)
+		}
+		b.WriteString("\n")
+
+		source, ok := sources[d.File]
+		lines := strings.Split(source, "\n")
+		if !ok || d.Line < 1 || int(d.Line) > len(lines) {
+			continue
+		}
+		line := strings.TrimRight(lines[d.Line-1], "\r")
+		gutter := fmt.Sprintf("%5d | ", d.Line)
+		fmt.Fprintf(&b, "%s%s\n", gutter, line)
+		fmt.Fprintf(&b, "%s%s\n", strings.Repeat(" ", len(gutter)-2)+"| ", caretLine(line, d.Column, d.EndColumn))
+
+		for _, fix := range d.Fixits {
+			fmt.Fprintf(&b, "      fix: %s\n", describeFixIt(fix))
+		}
+	}
+	return b.String()
+}
+
+// caretLine marks column with ^ and the rest of the range up to end with ~,
+// keeping tabs so the marker lines up under the source line
+func caretLine(line string, column, end int32) string {
+	if column < 1 {
+		return ""
+	}
+	var b strings.Builder
+	for i := int32(1); i < column; i++ {
+		if int(i) <= len(line) && line[i-1] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteByte('^')
+	for i := column + 1; i < end; i++ {
+		b.WriteByte('~')
+	}
+	return b.String()
+}
+
+func describeFixIt(fix *pb.FixIt) string {
+	switch {
+	case fix.Replacement == "":
+		return fmt.Sprintf("remove line %d columns %d-%d", fix.Line, fix.Column, fix.EndColumn-1)
+	case fix.Line == fix.EndLine && fix.Column == fix.EndColumn:
+		return fmt.Sprintf("insert %q at line %d column %d", fix.Replacement, fix.Line, fix.Column)
+	default:
+		return fmt.Sprintf("replace line %d columns %d-%d with %q", fix.Line, fix.Column, fix.EndColumn-1, fix.Replacement)
+	}
+}
+
+func severityName(severity pb.DiagnosticSeverity) string {
+	switch severity {
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_ERROR:
+		return "error"
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_WARNING:
+		return "warning"
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_NOTE:
+		return "note"
+	default:
+		return "diagnostic"
+	}
+}
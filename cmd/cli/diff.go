@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is how many unchanged lines surround each change
+const diffContext = 3
+
+// diffLine is one line of a diff: kept (' '), removed ('-') or added ('+').
+// a and b are its 0-based positions in the old and new text.
+type diffLine struct {
+	kind byte
+	text string
+	a, b int
+}
+
+// unifiedDiff compares two texts line by line and returns the differences
+// in unified diff format, or "" when they are the same
+func unifiedDiff(nameA, nameB, a, b string) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	for i := 0; i < len(lines); {
+		for i < len(lines) && lines[i].kind == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		// A hunk runs until the next change is too far away to share its
+		// context
+		start, end := max(i-diffContext, 0), i
+		for j := i; j < len(lines); j++ {
+			if lines[j].kind != ' ' {
+				end = j
+			} else if j-end > 2*diffContext {
+				break
+			}
+		}
+		stop := min(end+diffContext+1, len(lines))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+		countA, countB := 0, 0
+		for _, l := range lines[start:stop] {
+			if l.kind != '+' {
+				countA++
+			}
+			if l.kind != '-' {
+				countB++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(lines[start].a, countA), hunkRange(lines[start].b, countB))
+		for _, l := range lines[start:stop] {
+			fmt.Fprintf(&out, "%c%s\n", l.kind, l.text)
+		}
+		i = stop
+	}
+	return out.String()
+}
+
+// diffLines aligns x and y along their longest common subsequence
+func diffLines(x, y []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, diffLine{' ', x[i], i, j})
+			i++
+			j++
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', x[i], i, j})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', y[j], i, j})
+			j++
+		}
+	}
+	return lines
+}
+
+// hunkRange formats the start and length of one side of a hunk. An empty
+// side is given by the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprint(start + 1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes, so that scripts can tell outcomes apart
+const (
+	exitOK           = 0
+	exitError        = 1 // anything not covered below
+	exitTestsFailed  = 2
+	exitCompileError = 3
+	exitNotFound     = 4
+	exitInvalid      = 5 // the server rejected the request
+	exitLocked       = 6 // a precondition, such as prerequisites, is not met
+	exitBusy         = 7
+	exitUnavailable  = 8 // the server could not be reached or timed out
+)
+
+// exitCodeError ends the CLI with a specific exit code. Its message, when not
+// empty, is printed first; outcomes already reported leave it empty.
+type exitCodeError struct {
+	code int
+	msg  string
+}
+
+func (e *exitCodeError) Error() string { return e.msg }
+
+// exit reports err in words a student can act on and exits with the code
+// matching it
+func exit(err error) {
+	if err == nil {
+		os.Exit(exitOK)
+	}
+	var exitErr *exitCodeError
+	if errors.As(err, &exitErr) {
+		if exitErr.msg != "" {
+			fmt.Fprintln(os.Stderr, exitErr.msg)
+		}
+		os.Exit(exitErr.code)
+	}
+	msg, code := describeError(err)
+	fmt.Fprintln(os.Stderr, msg)
+	os.Exit(code)
+}
+
+// describeError explains an error from the server using its status code
+// and details. Errors that did not come from the server are shown as is.
+func describeError(err error) (string, int) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error(), exitError
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		for _, d := range st.Details() {
+			info, ok := d.(*errdetails.ResourceInfo)
+			if !ok {
+				continue
+			}
+			switch info.ResourceType {
+			case "lesson":
+				return fmt.Sprintf("Lesson %s does not exist. Run 'cli list' to see the available lessons.", info.ResourceName), exitNotFound
+			case "solution":
+				return fmt.Sprintf("Lesson %s has no reference solution.", info.ResourceName), exitNotFound
+			}
+		}
+		return "Not found: " + st.Message(), exitNotFound
+
+	case codes.InvalidArgument:
+		var lines []string
+		for _, d := range st.Details() {
+			if br, ok := d.(*errdetails.BadRequest); ok {
+				for _, v := range br.FieldViolations {
+					lines = append(lines, fmt.Sprintf("  %s: %s", v.Field, v.Description))
+				}
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, "  "+st.Message())
+		}
+		return "The server rejected the submission:\n" + strings.Join(lines, "\n"), exitInvalid
+
+	case codes.FailedPrecondition:
+		var lines []string
+		for _, d := range st.Details() {
+			if pf, ok := d.(*errdetails.PreconditionFailure); ok {
+				for _, v := range pf.Violations {
+					lines = append(lines, v.Description)
+				}
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, st.Message())
+		}
+		return strings.Join(lines, "\n"), exitLocked
+
+	case codes.ResourceExhausted:
+		msg := "The server is busy: " + st.Message()
+		for _, d := range st.Details() {
+			if retry, ok := d.(*errdetails.RetryInfo); ok && retry.RetryDelay != nil {
+				msg += fmt.Sprintf("\nTry again in %s.", retry.RetryDelay.AsDuration().Round(time.Second))
+			}
+		}
+		return msg, exitBusy
+
+	case codes.Unavailable:
+		return fmt.Sprintf("Cannot reach the c-learning server at %s. Is it running?", serverAddr), exitUnavailable
+
+	case codes.DeadlineExceeded, codes.Canceled:
+		return "The server did not answer in time: " + st.Message(), exitUnavailable
+
+	case codes.Internal, codes.Unknown:
+		return "The server ran into a problem: " + st.Message(), exitError
+
+	default:
+		return st.Message(), exitError
+	}
+}
@@ -5,26 +5,35 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"google.golang.org/grpc"
 )
 
+// serverAddr is where the c-learning server listens
+const serverAddr = "localhost:50052"
+
 func main() {
 	testCmd := flag.NewFlagSet("test", flag.ExitOnError)
+	sanitize := testCmd.String("sanitize", "", "Comma-separated sanitizers to build with: address, undefined, leak")
+	memoryChecker := testCmd.String("memcheck", "", "Memory checker to run tests under: valgrind")
 	nextCmd := flag.NewFlagSet("next", flag.ExitOnError)
 	progressCmd := flag.NewFlagSet("progress", flag.ExitOnError)
 	initCmd := flag.NewFlagSet("init", flag.ExitOnError)
+	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	hintCmd := flag.NewFlagSet("hint", flag.ExitOnError)
+	solutionCmd := flag.NewFlagSet("solution", flag.ExitOnError)
 
 	lessonCmd := flag.NewFlagSet("lesson", flag.ExitOnError)
 	lessonID := lessonCmd.Int("id", 1, "Lesson ID to start")
 
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: cli <command> [arguments]")
-		fmt.Println("Commands: lesson, test, next, progress, init")
+		fmt.Println("Commands: lesson, list, test, hint, solution, next, progress, init")
 		os.Exit(1)
 	}
 
-	conn, err := grpc.Dial("localhost:50052", grpc.WithInsecure())
+	conn, err := grpc.Dial(serverAddr, grpc.WithInsecure())
 	if err != nil {
 		log.Fatalf("failed to connect: %v", err)
 	}
@@ -35,37 +44,43 @@ func main() {
 	switch os.Args[1] {
 	case "lesson":
 		lessonCmd.Parse(os.Args[2:])
-		if err := cli.initLesson(int32(*lessonID)); err != nil {
-			log.Fatal(err)
-		}
+		exit(cli.initLesson(int32(*lessonID)))
 
 	case "test":
 		testCmd.Parse(os.Args[2:])
-		if err := cli.test(); err != nil {
-			log.Fatal(err)
+		var sanitizers []string
+		if *sanitize != "" {
+			sanitizers = strings.Split(*sanitize, ",")
 		}
+		exit(cli.test(sanitizers, *memoryChecker))
+
+	case "hint":
+		hintCmd.Parse(os.Args[2:])
+		exit(cli.hint())
+
+	case "solution":
+		solutionCmd.Parse(os.Args[2:])
+		exit(cli.solution())
 
 	case "next":
 		nextCmd.Parse(os.Args[2:])
-		if err := cli.next(); err != nil {
-			log.Fatal(err)
-		}
+		exit(cli.next())
 
 	case "progress":
 		progressCmd.Parse(os.Args[2:])
-		if err := cli.showProgress(); err != nil {
-			log.Fatal(err)
-		}
+		exit(cli.showProgress())
+
+	case "list":
+		listCmd.Parse(os.Args[2:])
+		exit(cli.listLessons())
 
 	case "init":
 		initCmd.Parse(os.Args[2:])
-		if err := cli.initWorkspace(); err != nil {
-			log.Fatal(err)
-		}
+		exit(cli.initWorkspace())
 
 	default:
 		fmt.Println("Usage: cli <command> [arguments]")
-		fmt.Println("Commands: lesson, test, next, progress, init")
+		fmt.Println("Commands: lesson, list, test, hint, solution, next, progress, init")
 		os.Exit(1)
 	}
 }
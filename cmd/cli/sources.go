@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ignoreFile lists glob patterns, one per line, of workspace files that
+// 'cli test' should not submit. A pattern matches either the file's path
+// relative to the workspace or its base name.
+const ignoreFile = ".clearningignore"
+
+// referenceFile is where 'cli solution' writes the lesson's reference
+// solution. It is never submitted, as it defines its own main.
+const referenceFile = "reference_solution.c"
+
+// readSources returns the .c and .h files under dir by slash-separated
+// relative path, skipping hidden files and directories, the reference
+// solution and anything matched by the workspace's ignore file
+func readSources(dir string) (map[string]string, error) {
+	ignore, err := readIgnorePatterns(filepath.Join(dir, ignoreFile))
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]string)
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if strings.HasPrefix(d.Name(), ".") || rel == referenceFile || ignored(ignore, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || (path.Ext(rel) != ".c" && path.Ext(rel) != ".h") {
+			return nil
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sources[rel] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources: %v", err)
+	}
+	return sources, nil
+}
+
+// readIgnorePatterns reads an ignore file, skipping blank lines and
+// comments. A missing file ignores nothing.
+func readIgnorePatterns(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", ignoreFile, err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern := strings.Trim(line, "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q", ignoreFile, line)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, scanner.Err()
+}
+
+func ignored(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
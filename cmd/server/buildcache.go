@@ -0,0 +1,376 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// cacheMetaFile holds a cached build's result next to its binaries
+const cacheMetaFile = "build.json"
+
+// buildCache keeps the results of compiling submissions on disk, keyed by
+// a hash of everything that goes into the build, so that resubmitting
+// unchanged code skips the compiler. Failed builds are cached too, as their
+// diagnostics are just as deterministic. The least recently used entries
+// are evicted once the cache grows beyond maxBytes.
+type buildCache struct {
+	dir      string
+	maxBytes int64
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element // of *cacheEntry, by key
+	lru      *list.List               // most recently used first
+	size     int64
+	versions map[string]string // compiler version, by compiler
+
+	hits, misses, evictions expvar.Int
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+
+	// readers counts gets copying out of the entry. The files of a removed
+	// entry are only deleted once the last of them is done.
+	readers int
+	removed bool
+}
+
+// cachedBuild is what build.json holds
+type cachedBuild struct {
+	Status      ExecStatus        `json:"status"`
+	Output      string            `json:"output"`
+	Diagnostics []*pb.Diagnostic  `json:"diagnostics"`
+	Commands    []string          `json:"commands"`
+	Binaries    map[string]string `json:"binaries"` // file name in the entry, by harness
+	Dir         string            `json:"dir"`
+}
+
+// openBuildCache opens the cache in dir, keeping the entries a previous
+// run left there
+func openBuildCache(dir string, maxBytes int64) (*buildCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create build cache: %v", err)
+	}
+	c := &buildCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		versions: make(map[string]string),
+	}
+
+	dirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build cache: %v", err)
+	}
+	type found struct {
+		entry *cacheEntry
+		used  time.Time
+	}
+	var existing []found
+	for _, d := range dirs {
+		path := filepath.Join(dir, d.Name())
+		meta, err := os.Stat(filepath.Join(path, cacheMetaFile))
+		if !d.IsDir() || err != nil {
+			// Left behind by a store that did not finish
+			os.RemoveAll(path)
+			continue
+		}
+		existing = append(existing, found{&cacheEntry{key: d.Name(), size: dirSize(path)}, meta.ModTime()})
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].used.After(existing[j].used) })
+	for _, f := range existing {
+		c.entries[f.entry.key] = c.lru.PushBack(f.entry)
+		c.size += f.entry.size
+	}
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+	return c, nil
+}
+
+// key identifies a build of sources for lesson, or is empty when the
+// compiler's version is unknown and the build cannot be cached
+func (c *buildCache) key(sources map[string]string, lesson *Lesson, checkers checkerOptions) string {
+	if c == nil {
+		return ""
+	}
+	version := c.compilerVersion(lesson.Build.Compiler)
+	if version == "" {
+		return ""
+	}
+
+	h := sha256.New()
+	field := func(s string) {
+		fmt.Fprintf(h, "%d:%s\n", len(s), s)
+	}
+	field(version)
+	field(lesson.Type)
+	for _, args := range [][]string{lesson.Build.cflags(), lesson.Build.Libs, lesson.Build.diagnosticFlags(), checkers.compilerFlags()} {
+		field(strings.Join(args, "\x00"))
+	}
+	for _, files := range []map[string]string{sources, lesson.Harnesses} {
+		field(fmt.Sprint(len(files)))
+		for _, name := range sortedKeys(files) {
+			field(name)
+			field(files[name])
+		}
+	}
+	if lesson.Type == lessonTypeUnit {
+		field(string(harnessHeader))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// compilerVersion returns the first line of `compiler --version`, which
+// changes whenever the compiler is upgraded
+func (c *buildCache) compilerVersion(compiler string) string {
+	c.mu.Lock()
+	version, ok := c.versions[compiler]
+	c.mu.Unlock()
+	if ok {
+		return version
+	}
+
+	out, err := exec.Command(compiler, "--version").Output()
+	if err == nil {
+		version, _, _ = strings.Cut(string(out), "\n")
+	}
+	c.mu.Lock()
+	c.versions[compiler] = version
+	c.mu.Unlock()
+	return version
+}
+
+// get copies the binaries of the build cached under key into tmpDir and
+// returns the build, or nil on a miss
+func (c *buildCache) get(key, tmpDir string) *buildResult {
+	if key == "" {
+		return nil
+	}
+	c.mu.Lock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		c.misses.Add(1)
+		return nil
+	}
+	entry := elem.Value.(*cacheEntry)
+	entry.readers++
+	c.lru.MoveToFront(elem)
+	c.mu.Unlock()
+
+	// Copied without the lock, so other workers are not held up; the
+	// pinned entry's files stay in place even if it is evicted meanwhile
+	path := filepath.Join(c.dir, key)
+	build, err := readCachedBuild(path, tmpDir)
+
+	c.mu.Lock()
+	entry.readers--
+	switch {
+	case err != nil && !entry.removed:
+		c.remove(elem)
+	case entry.removed && entry.readers == 0:
+		c.deleteFiles(entry)
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Dropping build cache entry %s: %v", key, err)
+		c.misses.Add(1)
+		return nil
+	}
+	now := time.Now()
+	os.Chtimes(filepath.Join(path, cacheMetaFile), now, now)
+	c.hits.Add(1)
+	return build
+}
+
+func readCachedBuild(path, tmpDir string) (*buildResult, error) {
+	data, err := os.ReadFile(filepath.Join(path, cacheMetaFile))
+	if err != nil {
+		return nil, err
+	}
+	var meta cachedBuild
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	build := &buildResult{
+		status:      meta.Status,
+		output:      meta.Output,
+		diagnostics: meta.Diagnostics,
+		commands:    meta.Commands,
+		binaries:    make(map[string]string),
+		dir:         meta.Dir,
+		cached:      true,
+	}
+	for harness, name := range meta.Binaries {
+		binary := filepath.Join(tmpDir, name)
+		if err := copyFile(filepath.Join(path, name), binary, 0755); err != nil {
+			return nil, err
+		}
+		build.binaries[harness] = binary
+	}
+	return build, nil
+}
+
+// put stores build under key. Only builds whose outcome depends on nothing
+// but their inputs are kept: successful ones and compile errors, not
+// compilers that ran out of time or memory.
+func (c *buildCache) put(key string, build *buildResult) {
+	if key == "" || (build.status != StatusOK && build.status != StatusRuntimeError) {
+		return
+	}
+	c.mu.Lock()
+	_, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return
+	}
+
+	// Filled in beside the cache and renamed into place, so that a crash
+	// never leaves a partial entry under a valid key
+	tmp, err := os.MkdirTemp(c.dir, ".tmp-")
+	if err != nil {
+		log.Printf("Failed to cache build: %v", err)
+		return
+	}
+	defer os.RemoveAll(tmp)
+
+	meta := cachedBuild{
+		Status:      build.status,
+		Output:      build.output,
+		Diagnostics: build.diagnostics,
+		Commands:    build.commands,
+		Binaries:    make(map[string]string),
+		Dir:         build.dir,
+	}
+	for harness, binary := range build.binaries {
+		name := filepath.Base(binary)
+		if err := copyFile(binary, filepath.Join(tmp, name), 0755); err != nil {
+			log.Printf("Failed to cache build: %v", err)
+			return
+		}
+		meta.Binaries[harness] = name
+	}
+	data, err := json.Marshal(meta)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(tmp, cacheMetaFile), data, 0644)
+	}
+	if err != nil {
+		log.Printf("Failed to cache build: %v", err)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		// Another worker built the same submission meanwhile
+		return
+	}
+	if err := os.Rename(tmp, filepath.Join(c.dir, key)); err != nil {
+		log.Printf("Failed to cache build: %v", err)
+		return
+	}
+	entry := &cacheEntry{key: key, size: dirSize(filepath.Join(c.dir, key))}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size
+	c.evict()
+}
+
+// evict removes the least recently used entries until the cache fits in
+// maxBytes. c.mu must be held.
+func (c *buildCache) evict() {
+	for c.size > c.maxBytes && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// remove deletes an entry, leaving its files to the last reader if it is
+// being copied from. c.mu must be held.
+func (c *buildCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+	entry.removed = true
+	if entry.readers == 0 {
+		c.deleteFiles(entry)
+	}
+}
+
+// deleteFiles deletes a removed entry's directory. c.mu must be held, so
+// that a put of the same key cannot race with it.
+func (c *buildCache) deleteFiles(entry *cacheEntry) {
+	if err := os.RemoveAll(filepath.Join(c.dir, entry.key)); err != nil {
+		log.Printf("Failed to remove build cache entry %s: %v", entry.key, err)
+	}
+}
+
+// stats reports the cache's size and hit rate, for /debug/vars
+func (c *buildCache) stats() any {
+	c.mu.Lock()
+	entries, size := c.lru.Len(), c.size
+	c.mu.Unlock()
+
+	hits, misses := c.hits.Value(), c.misses.Value()
+	hitRate := 0.0
+	if hits+misses > 0 {
+		hitRate = float64(hits) / float64(hits+misses)
+	}
+	return map[string]any{
+		"entries":   entries,
+		"bytes":     size,
+		"max_bytes": c.maxBytes,
+		"hits":      hits,
+		"misses":    misses,
+		"evictions": c.evictions.Value(),
+		"hit_rate":  hitRate,
+	}
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// dirSize returns the total size of the files directly in dir
+func dirSize(dir string) int64 {
+	files, _ := os.ReadDir(dir)
+	var size int64
+	for _, f := range files {
+		if info, err := f.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
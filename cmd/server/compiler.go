@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// Compilers accepted in the "compiler" field of a lesson's build config
+const (
+	compilerGCC   = "gcc"
+	compilerClang = "clang"
+)
+
+// defaultWarningFlags are always passed before a lesson's own flags, so
+// that warnings fail a submission unless a lesson opts out
+var defaultWarningFlags = []string{"-Wall", "-Werror"}
+
+var (
+	cStandards = map[string]bool{"c89": true, "c99": true, "c11": true, "c17": true}
+	defineRe   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(=.*)?$`)
+	libraryRe  = regexp.MustCompile(`^[A-Za-z0-9_+.-]+$`)
+)
+
+// BuildConfig is how a lesson's submissions are compiled, both by the
+// grader and by the Makefile the CLI generates.
+type BuildConfig struct {
+	Compiler string   `json:"compiler"` // gcc (default) or clang
+	Std      string   `json:"std"`      // c89, c99, c11 or c17; the compiler's default when empty
+	Flags    []string `json:"flags"`    // extra flags after the default warnings
+	Libs     []string `json:"libs"`     // libraries to link, e.g. "m" or "-lm"
+	Defines  []string `json:"defines"`  // NAME or NAME=VALUE
+}
+
+// prepare validates c and fills in defaults, normalising libraries to -l
+// flags
+func (c *BuildConfig) prepare() error {
+	switch c.Compiler {
+	case "":
+		c.Compiler = compilerGCC
+	case compilerGCC, compilerClang:
+	default:
+		return fmt.Errorf("unknown compiler %q (want %s or %s)", c.Compiler, compilerGCC, compilerClang)
+	}
+	if c.Std != "" && !cStandards[c.Std] {
+		return fmt.Errorf("unknown C standard %q (want c89, c99, c11 or c17)", c.Std)
+	}
+
+	for _, flag := range c.Flags {
+		// Output names are chosen by the grader
+		if !strings.HasPrefix(flag, "-") || strings.HasPrefix(flag, "-o") {
+			return fmt.Errorf("invalid compiler flag %q", flag)
+		}
+	}
+	for i, lib := range c.Libs {
+		lib = strings.TrimPrefix(lib, "-l")
+		if !libraryRe.MatchString(lib) {
+			return fmt.Errorf("invalid library %q", c.Libs[i])
+		}
+		c.Libs[i] = "-l" + lib
+	}
+	for _, define := range c.Defines {
+		if !defineRe.MatchString(define) {
+			return fmt.Errorf("invalid define %q", define)
+		}
+	}
+	return nil
+}
+
+// cflags returns the flags used to compile each source file
+func (c BuildConfig) cflags() []string {
+	var flags []string
+	if c.Std != "" {
+		flags = append(flags, "-std="+c.Std)
+	}
+	flags = append(flags, defaultWarningFlags...)
+	flags = append(flags, c.Flags...)
+	for _, define := range c.Defines {
+		flags = append(flags, "-D"+define)
+	}
+	return flags
+}
+
+// proto describes the config for clients building locally
+func (c BuildConfig) proto() *pb.BuildConfig {
+	return &pb.BuildConfig{
+		Compiler: c.Compiler,
+		Std:      c.Std,
+		Flags:    append(append([]string(nil), defaultWarningFlags...), c.Flags...),
+		Libs:     c.Libs,
+		Defines:  c.Defines,
+	}
+}
+
+// diagnosticFlags asks the compiler for output parseCompilerOutput can
+// turn into structured diagnostics
+func (c BuildConfig) diagnosticFlags() []string {
+	if c.Compiler == compilerClang {
+		// The caret lines would end up in the plain text output
+		return []string{"-fno-color-diagnostics", "-fno-caret-diagnostics", "-fdiagnostics-parseable-fixits"}
+	}
+	return []string{"-fdiagnostics-format=json"}
+}
+
+// commandLine renders a command the way a shell would need it typed
+func commandLine(name string, args []string) string {
+	quoted := []string{name}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`*?[]{}()<>|&;#~!") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// gccDiagnostic mirrors one entry of gcc's -fdiagnostics-format=json output
+type gccDiagnostic struct {
+	Kind      string `json:"kind"`
+	Message   string `json:"message"`
+	Option    string `json:"option"`
+	Locations []struct {
+		Caret  gccLocation  `json:"caret"`
+		Finish *gccLocation `json:"finish"`
+	} `json:"locations"`
+	Fixits []struct {
+		Start  gccLocation `json:"start"`
+		Next   gccLocation `json:"next"`
+		String string      `json:"string"`
+	} `json:"fixits"`
+	Children []gccDiagnostic `json:"children"`
+}
+
+type gccLocation struct {
+	File   string `json:"file"`
+	Line   int32  `json:"line"`
+	Column int32  `json:"column"`
+}
+
+var (
+	// textDiagnosticRe matches clang's diagnostics, e.g.
+	// "solution.c:3:9: error: unused variable 'x' [-Werror,-Wunused-variable]"
+	textDiagnosticRe = regexp.MustCompile(`^(\S+?):(\d+):(\d+): (fatal error|error|warning|note): (.*?)(?: \[([^\]]+)\])?$`)
+
+	// fixItRe matches clang's -fdiagnostics-parseable-fixits lines, e.g.
+	// fix-it:"solution.c":{3:12-3:12}:";"
+	fixItRe = regexp.MustCompile(`^fix-it:"([^"]*)":\{(\d+):(\d+)-(\d+):(\d+)\}:"(.*)"$`)
+)
+
+// parseCompilerOutput extracts the JSON diagnostics gcc prints (one array
+// per translation unit), or clang's text diagnostics, and returns them along
+// with any remaining plain text, such as linker errors.
+func parseCompilerOutput(output string) ([]*pb.Diagnostic, string) {
+	var diags []*pb.Diagnostic
+	var rest []string
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			var parsed []gccDiagnostic
+			if err := json.Unmarshal([]byte(trimmed), &parsed); err == nil {
+				for _, d := range parsed {
+					diags = appendDiagnostic(diags, d)
+				}
+				continue
+			}
+		}
+		if m := textDiagnosticRe.FindStringSubmatch(trimmed); m != nil {
+			diags = append(diags, textDiagnostic(m))
+			continue
+		}
+		if m := fixItRe.FindStringSubmatch(trimmed); m != nil && len(diags) > 0 {
+			last := diags[len(diags)-1]
+			last.Fixits = append(last.Fixits, &pb.FixIt{
+				Line:        atoi32(m[2]),
+				Column:      atoi32(m[3]),
+				EndLine:     atoi32(m[4]),
+				EndColumn:   atoi32(m[5]),
+				Replacement: strings.ReplaceAll(m[6], `\"`, `"`),
+			})
+			continue
+		}
+		if trimmed != "" {
+			rest = append(rest, line)
+		}
+	}
+	return diags, strings.Join(rest, "\n")
+}
+
+// appendDiagnostic converts d and its child notes, flattened in order
+func appendDiagnostic(diags []*pb.Diagnostic, d gccDiagnostic) []*pb.Diagnostic {
+	diag := &pb.Diagnostic{
+		Severity: diagnosticSeverity(d.Kind),
+		Message:  d.Message,
+		Option:   d.Option,
+	}
+	if len(d.Locations) > 0 {
+		loc := d.Locations[0]
+		diag.File = loc.Caret.File
+		diag.Line = loc.Caret.Line
+		diag.Column = loc.Caret.Column
+		if loc.Finish != nil && loc.Finish.Line == loc.Caret.Line {
+			// gcc's finish is the last highlighted column, inclusive
+			diag.EndColumn = loc.Finish.Column + 1
+		}
+	}
+	for _, f := range d.Fixits {
+		diag.Fixits = append(diag.Fixits, &pb.FixIt{
+			Line:        f.Start.Line,
+			Column:      f.Start.Column,
+			EndLine:     f.Next.Line,
+			EndColumn:   f.Next.Column,
+			Replacement: f.String,
+		})
+	}
+
+	diags = append(diags, diag)
+	for _, child := range d.Children {
+		diags = appendDiagnostic(diags, child)
+	}
+	return diags
+}
+
+// textDiagnostic converts a match of textDiagnosticRe
+func textDiagnostic(m []string) *pb.Diagnostic {
+	diag := &pb.Diagnostic{
+		File:     m[1],
+		Line:     atoi32(m[2]),
+		Column:   atoi32(m[3]),
+		Severity: diagnosticSeverity(m[4]),
+		Message:  m[5],
+	}
+	// clang lists -Werror before the warning's own option
+	if options := strings.Split(m[6], ","); m[6] != "" {
+		diag.Option = options[len(options)-1]
+	}
+	return diag
+}
+
+func atoi32(s string) int32 {
+	n, _ := strconv.Atoi(s)
+	return int32(n)
+}
+
+func diagnosticSeverity(kind string) pb.DiagnosticSeverity {
+	switch {
+	case strings.Contains(kind, "error"), kind == "ice", kind == "sorry":
+		return pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_ERROR
+	case strings.Contains(kind, "warning"), kind == "pedwarn":
+		return pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_WARNING
+	case kind == "note":
+		return pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_NOTE
+	default:
+		return pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_UNSPECIFIED
+	}
+}
+
+// formatDiagnostics renders diagnostics one per line the way gcc does in
+// text mode, e.g. "solution.c:3:9: error: unused variable 'x'"
+func formatDiagnostics(diags []*pb.Diagnostic) string {
+	var b strings.Builder
+	for _, d := range diags {
+		if d.File != "" {
+			fmt.Fprintf(&b, "%s:%d:%d: ", d.File, d.Line, d.Column)
+		}
+		fmt.Fprintf(&b, "%s: %s", severityName(d.Severity), d.Message)
+		if d.Option != "" {
+			fmt.Fprintf(&b, " [%s]", d.Option)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func severityName(severity pb.DiagnosticSeverity) string {
+	switch severity {
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_ERROR:
+		return "error"
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_WARNING:
+		return "warning"
+	case pb.DiagnosticSeverity_DIAGNOSTIC_SEVERITY_NOTE:
+		return "note"
+	default:
+		return "diagnostic"
+	}
+}
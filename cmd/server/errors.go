@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// statusWithDetails builds a status error carrying errdetails payloads for
+// clients that want more than the message
+func statusWithDetails(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st, err := status.New(code, msg).WithDetails(details...)
+	if err != nil {
+		// Only fails for a nil detail or an OK code
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+// lessonNotFound is returned for requests naming a lesson that does not exist
+func lessonNotFound(lessonID int32) error {
+	return statusWithDetails(codes.NotFound, fmt.Sprintf("lesson %d not found", lessonID),
+		&errdetails.ResourceInfo{
+			ResourceType: "lesson",
+			ResourceName: fmt.Sprint(lessonID),
+			Description:  "no lesson with this ID is loaded",
+		})
+}
+
+// solutionNotFound is returned when asking for the reference solution of a
+// lesson that does not ship one
+func solutionNotFound(lessonID int32) error {
+	return statusWithDetails(codes.NotFound, fmt.Sprintf("lesson %d has no reference solution", lessonID),
+		&errdetails.ResourceInfo{
+			ResourceType: "solution",
+			ResourceName: fmt.Sprint(lessonID),
+			Description:  "the lesson does not ship a solution.c",
+		})
+}
+
+// invalidField rejects a request because of the value of one of its fields
+func invalidField(field string, err error) error {
+	return statusWithDetails(codes.InvalidArgument, err.Error(),
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: err.Error()}},
+		})
+}
+
+// preconditionFailed rejects a request the server cannot serve in its
+// current state, e.g. a lesson whose prerequisites are not completed
+func preconditionFailed(kind, subject, description string) error {
+	return statusWithDetails(codes.FailedPrecondition, description,
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{Type: kind, Subject: subject, Description: description}},
+		})
+}
+
+// resourceExhausted rejects a request that exceeded a quota, telling the
+// client when it is worth trying again
+func resourceExhausted(subject, description string, retryAfter time.Duration) error {
+	return statusWithDetails(codes.ResourceExhausted, description,
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{Subject: subject, Description: description}},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+}
+
+// toStatus turns an error a handler returned into a gRPC status error.
+// Errors that are not already statuses are server faults: they are logged
+// and reported as INTERNAL.
+func toStatus(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	log.Printf("%s failed: %v", method, err)
+	return status.Error(codes.Internal, err.Error())
+}
+
+// recoverPanic turns a panic in a handler into an INTERNAL error, so that
+// one bad submission cannot take the whole server down. It must be
+// deferred directly.
+func recoverPanic(method string, err *error) {
+	if r := recover(); r != nil {
+		log.Printf("%s panicked: %v\n%s", method, r, debug.Stack())
+		*err = status.Error(codes.Internal, "the server failed to handle this request")
+	}
+}
+
+// unaryErrorInterceptor applies toStatus to every unary handler's error,
+// and recovers from panics
+func unaryErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer recoverPanic(info.FullMethod, &err)
+	resp, err = handler(ctx, req)
+	return resp, toStatus(info.FullMethod, err)
+}
+
+// streamErrorInterceptor applies toStatus to every streaming handler's
+// error, and recovers from panics
+func streamErrorInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverPanic(info.FullMethod, &err)
+	return toStatus(info.FullMethod, handler(srv, ss))
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"expvar"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// openAPISpec is generated by the openapiv2 plugin in buf.gen.yaml.
+//
+//go:embed openapi/clearning.swagger.json
+var openAPISpec []byte
+
+// newGatewayHandler returns an HTTP handler that serves the REST API by
+// proxying to the gRPC server at grpcAddr, plus the OpenAPI document at
+// /openapi.json. Going through the gRPC port rather than calling the
+// server in-process keeps streaming RPCs and interceptors working.
+func newGatewayHandler(ctx context.Context, grpcAddr string) (http.Handler, error) {
+	gw := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterLearningServiceHandlerFromEndpoint(ctx, gw, dialTarget(grpcAddr), opts); err != nil {
+		return nil, fmt.Errorf("failed to register gateway: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gw)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPISpec)
+	})
+	return mux, nil
+}
+
+// newAdminHandler serves server metrics, such as the build cache's hit
+// rate, at /debug/vars. It belongs on a listener only operators can reach.
+func newAdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// gatewayHeaderMatcher forwards the user ID header as gRPC metadata in
+// addition to the gateway's default header mapping.
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, userIDMetadataKey) {
+		return userIDMetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// dialTarget turns a listen address such as ":50052" into one that can be dialed
+func dialTarget(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
@@ -1,15 +1,38 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	pb "github.com/afshin-deriv/c-learning/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// userIDMetadataKey is the request metadata that identifies the caller
+const userIDMetadataKey = "x-user-id"
+
+// defaultTrack holds lessons placed directly under the lessons directory
+const defaultTrack = "general"
+
+// eventSink receives progress events while a submission is graded
+type eventSink func(*pb.ValidationEvent) error
+
+// send delivers ev if anyone is listening
+func (e eventSink) send(ev *pb.ValidationEvent) error {
+	if e == nil {
+		return nil
+	}
+	return e(ev)
+}
+
 // convertTestCases converts internal TestCase format to protobuf format
 func convertTestCases(cases []TestCase) []*pb.TestCase {
 	result := make([]*pb.TestCase, len(cases))
@@ -18,7 +41,20 @@ func convertTestCases(cases []TestCase) []*pb.TestCase {
 			Input:          tc.Input,
 			ExpectedOutput: tc.Expected,
 			Description:    tc.Description,
+			Matcher:        tc.Matcher,
+			Args:           tc.Args,
+			Env:            tc.Env,
+			Files:          tc.Files,
+			ExpectedFiles:  tc.ExpectedFiles,
+			Harness:        tc.Harness,
+			Points:         tc.points(),
+			Tolerance:      tc.Tolerance,
+		}
+		if tc.ExpectedExitCode != nil {
+			code := int32(*tc.ExpectedExitCode)
+			result[i].ExpectedExitCode = &code
 		}
+		result[i].ExpectedStderr = tc.ExpectedStderr
 	}
 	return result
 }
@@ -44,13 +80,89 @@ func getFeedback(allPassed bool, results []*pb.TestResult) string {
 		failedCount, len(results))
 }
 
-// validatePrerequisites checks if user has completed required prerequisites
+// requestUserID returns the user a request is made for, taken from the
+// request itself or, failing that, from the x-user-id metadata
+func requestUserID(ctx context.Context, userID string) string {
+	if userID != "" {
+		return userID
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(userIDMetadataKey); len(ids) > 0 {
+			return ids[0]
+		}
+	}
+	return ""
+}
+
+// recordSubmission stores a graded attempt and, when it passed, marks the
+// lesson as completed for the user
+func (s *server) recordSubmission(ctx context.Context, userID string, lessonID int32, files map[string]string, passed bool, score float32) error {
+	err := s.store.AddSubmission(ctx, &Submission{
+		UserID:    userID,
+		LessonID:  lessonID,
+		Code:      files[solutionFile],
+		Files:     files,
+		Passed:    passed,
+		Score:     score,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record submission: %v", err)
+	}
+
+	if !passed {
+		return nil
+	}
+	if err := s.updateProgress(ctx, userID, lessonID); err != nil {
+		return fmt.Errorf("failed to update progress: %v", err)
+	}
+	return nil
+}
+
+// sortedLessons returns all lessons in curriculum order
+func (s *server) sortedLessons() []*Lesson {
+	all := s.allLessons()
+	lessons := make([]*Lesson, 0, len(all))
+	for _, lesson := range all {
+		lessons = append(lessons, lesson)
+	}
+	sort.Slice(lessons, func(i, j int) bool {
+		if lessons[i].Order != lessons[j].Order {
+			return lessons[i].Order < lessons[j].Order
+		}
+		return lessons[i].ID < lessons[j].ID
+	})
+	return lessons
+}
+
+// lessonStatus reports whether a lesson is completed, available or still
+// locked behind its prerequisites for the given progress
+func (s *server) lessonStatus(lessonID int32, progress *UserProgress) pb.LessonStatus {
+	for _, completed := range progress.CompletedLessons {
+		if completed == lessonID {
+			return pb.LessonStatus_LESSON_STATUS_COMPLETED
+		}
+	}
+	if s.validatePrerequisites(lessonID, progress) {
+		return pb.LessonStatus_LESSON_STATUS_AVAILABLE
+	}
+	return pb.LessonStatus_LESSON_STATUS_LOCKED
+}
+
+// validatePrerequisites checks if user has completed required prerequisites.
+// Every lesson is open when the server lets students roam freely.
 func (s *server) validatePrerequisites(lessonID int32, progress *UserProgress) bool {
-	lesson, ok := s.lessons[lessonID]
+	lesson, ok := s.lesson(lessonID)
 	if !ok {
 		return false
 	}
+	return s.freeRoam || len(missingPrerequisites(lesson, progress)) == 0
+}
 
+// missingPrerequisites returns the prerequisites of lesson the user has not
+// completed yet
+func missingPrerequisites(lesson *Lesson, progress *UserProgress) []int32 {
+	var missing []int32
 	for _, prereq := range lesson.Prerequisites {
 		found := false
 		for _, completed := range progress.CompletedLessons {
@@ -60,88 +172,479 @@ func (s *server) validatePrerequisites(lessonID int32, progress *UserProgress) b
 			}
 		}
 		if !found {
-			return false
+			missing = append(missing, prereq)
 		}
 	}
-	return true
+	return missing
+}
+
+// checkPrerequisites fails with FAILED_PRECONDITION unless userID may work
+// on lesson. Anonymous requests only reach lessons without prerequisites.
+func (s *server) checkPrerequisites(ctx context.Context, userID string, lesson *Lesson) error {
+	if s.freeRoam || len(lesson.Prerequisites) == 0 {
+		return nil
+	}
+	progress := newUserProgress()
+	if userID != "" {
+		var err error
+		if progress, err = s.store.GetProgress(ctx, userID); err != nil {
+			return err
+		}
+	}
+	missing := missingPrerequisites(lesson, progress)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(missing))
+	for i, id := range missing {
+		ids[i] = fmt.Sprint(id)
+	}
+	noun := "lesson"
+	if len(missing) > 1 {
+		noun = "lessons"
+	}
+	return preconditionFailed("PREREQUISITES", fmt.Sprintf("lesson %d", lesson.ID),
+		fmt.Sprintf("Lesson %d is locked: complete %s %s first", lesson.ID, noun, strings.Join(ids, ", ")))
 }
 
 // updateProgress updates user progress after successful completion
-func (s *server) updateProgress(userID string, lessonID int32) {
-	progress, ok := s.userProgress[userID]
-	if !ok {
-		progress = &UserProgress{
-			CurrentLesson:    1,
-			CompletedLessons: []int32{},
+func (s *server) updateProgress(ctx context.Context, userID string, lessonID int32) error {
+	_, err := s.store.UpdateProgress(ctx, userID, func(progress *UserProgress) error {
+		// Check if lesson is already completed
+		for _, completed := range progress.CompletedLessons {
+			if completed == lessonID {
+				return nil
+			}
 		}
+
+		// Add to completed lessons
+		progress.CompletedLessons = append(progress.CompletedLessons, lessonID)
+
+		// Update current lesson if this was the current one
+		if progress.CurrentLesson == lessonID {
+			progress.CurrentLesson = lessonID + 1
+		}
+		return nil
+	})
+	return err
+}
+
+// getNextAvailableLesson finds the next lesson user can take: the first
+// one in curriculum order that is neither completed nor locked. It returns
+// nil once every lesson is completed.
+func (s *server) getNextAvailableLesson(ctx context.Context, userID string) (*Lesson, error) {
+	progress, err := s.store.GetProgress(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if lesson is already completed
-	for _, completed := range progress.CompletedLessons {
-		if completed == lessonID {
-			return
+	remaining := 0
+	for _, lesson := range s.sortedLessons() {
+		switch s.lessonStatus(lesson.ID, progress) {
+		case pb.LessonStatus_LESSON_STATUS_AVAILABLE:
+			return lesson, nil
+		case pb.LessonStatus_LESSON_STATUS_LOCKED:
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return nil, preconditionFailed("PREREQUISITES", "curriculum",
+			fmt.Sprintf("%d lessons remain but none of them can be started; their prerequisites cannot be met", remaining))
+	}
+	return nil, nil
+}
+
+// compileAndRunTests handles code compilation and test execution. The
+// build, with its compiler diagnostics, is returned even when compilation
+// fails. Cancelling ctx kills whatever is running and fails with an
+// interruption error naming the phase. Results of hidden tests are only
+// stripped when hide is set.
+func (s *server) compileAndRunTests(ctx context.Context, sources map[string]string, lesson *Lesson, checkers checkerOptions, tmpDir string, events eventSink, hide bool) ([]*pb.TestResult, *buildResult, error) {
+	err := events.send(&pb.ValidationEvent{
+		Event: &pb.ValidationEvent_CompileStarted{CompileStarted: &pb.CompileStarted{}},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := s.cache.key(sources, lesson, checkers)
+	build := s.cache.get(key, tmpDir)
+	if build == nil {
+		build, err = s.build(ctx, sources, lesson, checkers, tmpDir)
+		if ctx.Err() != nil {
+			return nil, nil, interrupted(ctx, "compiling")
+		}
+		if err != nil {
+			return nil, nil, err
 		}
+		s.cache.put(key, build)
 	}
 
-	// Add to completed lessons
-	progress.CompletedLessons = append(progress.CompletedLessons, lessonID)
+	err = events.send(&pb.ValidationEvent{
+		Event: &pb.ValidationEvent_CompileFinished{CompileFinished: &pb.CompileFinished{
+			Success:     build.status == StatusOK,
+			Output:      build.output,
+			Diagnostics: build.diagnostics,
+			Commands:    build.commands,
+			Cached:      build.cached,
+		}},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Update current lesson if this was the current one
-	if progress.CurrentLesson == lessonID {
-		progress.CurrentLesson = lessonID + 1
+	switch build.status {
+	case StatusOK:
+	case StatusRuntimeError:
+		return nil, build, fmt.Errorf("compilation failed:\n%s", build.output)
+	default:
+		return nil, build, fmt.Errorf("compilation failed (%s):\n%s", build.status, build.output)
 	}
 
-	s.userProgress[userID] = progress
+	var results []*pb.TestResult
+	for i, tc := range lesson.TestCases {
+		err := events.send(&pb.ValidationEvent{
+			Event: &pb.ValidationEvent_TestStarted{TestStarted: &pb.TestStarted{
+				Index:       int32(i),
+				Total:       int32(len(lesson.TestCases)),
+				Description: tc.Description,
+			}},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result, err := s.runTest(ctx, tc, build, filepath.Join(tmpDir, fmt.Sprintf("test-%d", i)), lesson.Limits, checkers)
+		if ctx.Err() != nil {
+			return nil, nil, interrupted(ctx, fmt.Sprintf("running test %d of %d (%s)", i+1, len(lesson.TestCases), tc.Description))
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to run test %q: %v", tc.Description, err)
+		}
+		scoreResult(result, tc)
+		if hide && tc.Hidden {
+			hideResult(result)
+		}
+		results = append(results, result)
+
+		err = events.send(&pb.ValidationEvent{
+			Event: &pb.ValidationEvent_TestFinished{TestFinished: &pb.TestFinished{
+				Index:  int32(i),
+				Result: result,
+			}},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return results, build, nil
 }
 
-// getNextAvailableLesson finds the next lesson user can take
-func (s *server) getNextAvailableLesson(userID string) int32 {
-	progress, ok := s.userProgress[userID]
-	if !ok {
-		return 1
+// interrupted reports that grading stopped in phase because the request was
+// cancelled or ran out of time
+func interrupted(ctx context.Context, phase string) error {
+	code := codes.Canceled
+	if ctx.Err() == context.DeadlineExceeded {
+		code = codes.DeadlineExceeded
 	}
+	return status.Errorf(code, "grading interrupted while %s: %v", phase, ctx.Err())
+}
+
+// buildResult is the outcome of compiling a submission
+type buildResult struct {
+	status      ExecStatus // of the first failing compiler step, or StatusOK
+	output      string     // human-readable compiler output
+	diagnostics []*pb.Diagnostic
+	commands    []string // command line of each compiler step run
+
+	// binaries maps a harness name to the executable linking it with the
+	// solution; plain program lessons have a single binary under "".
+	binaries map[string]string
 
-	current := progress.CurrentLesson
-	for {
-		if _, ok := s.lessons[current]; !ok {
-			return progress.CurrentLesson
+	// dir is where the compiler ran, which the binaries' debug info and
+	// so sanitizer reports refer to. It differs from where the binaries
+	// are for a build from the cache.
+	dir    string
+	cached bool
+}
+
+// build compiles the submission in tmpDir with the lesson's build config.
+// Program lessons build every .c file into one program. Unit lessons
+// compile them with main renamed and link them against each harness the
+// lesson's tests use.
+func (s *server) build(ctx context.Context, sources map[string]string, lesson *Lesson, checkers checkerOptions, tmpDir string) (*buildResult, error) {
+	// The compiler runs inside tmpDir with relative names so diagnostics
+	// mention solution.c rather than the temporary path.
+	for _, name := range sortedKeys(sources) {
+		file := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %v", name, err)
 		}
+		if err := os.WriteFile(file, []byte(sources[name]), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write source file %s: %v", name, err)
+		}
+	}
 
-		if s.validatePrerequisites(current, progress) {
-			return current
+	build := &buildResult{binaries: make(map[string]string), dir: tmpDir}
+	cfg := lesson.Build
+	cflags := append(cfg.cflags(), checkers.compilerFlags()...)
+	files := cSources(sources)
+	if lesson.Type != lessonTypeUnit {
+		args := append(append(append([]string{"-o", "solution"}, files...), cflags...), cfg.Libs...)
+		if err := s.compileStep(ctx, build, cfg, tmpDir, args); err != nil {
+			return nil, err
+		}
+		build.binaries[""] = filepath.Join(tmpDir, "solution")
+		return build, nil
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, harnessHeaderName), harnessHeader, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write harness header: %v", err)
+	}
+	args := append(append([]string{"-c", "-Dmain=" + studentMainName}, files...), cflags...)
+	err := s.compileStep(ctx, build, cfg, tmpDir, args)
+	if err != nil || build.status != StatusOK {
+		return build, err
+	}
+	var objects []string
+	for _, file := range files {
+		objects = append(objects, objectFile(file))
+	}
+
+	for _, name := range sortedKeys(lesson.Harnesses) {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(lesson.Harnesses[name]), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write harness %s: %v", name, err)
+		}
+		binary := "solution-" + strings.TrimSuffix(name, filepath.Ext(name))
+		args := append(append(append([]string{"-o", binary, name}, objects...), cflags...), cfg.Libs...)
+		if err := s.compileStep(ctx, build, cfg, tmpDir, args); err != nil {
+			return nil, err
 		}
+		if build.status != StatusOK {
+			return build, nil
+		}
+		build.binaries[name] = filepath.Join(tmpDir, binary)
+	}
+	return build, nil
+}
 
-		current++
+// compileStep runs the configured compiler with args in dir and adds its
+// output to build
+func (s *server) compileStep(ctx context.Context, build *buildResult, cfg BuildConfig, dir string, args []string) error {
+	args = append(args, cfg.diagnosticFlags()...)
+	build.commands = append(build.commands, commandLine(cfg.Compiler, args))
+	compile, err := s.sandbox.Run(ctx, RunSpec{
+		Path:        cfg.Compiler,
+		Args:        args,
+		Dir:         dir,
+		Limits:      compileLimits,
+		MergeStderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run compiler: %v", err)
 	}
+
+	// Linker errors are plain text even in JSON mode, so keep what is left
+	diagnostics, rest := parseCompilerOutput(string(compile.Stdout))
+	build.diagnostics = append(build.diagnostics, diagnostics...)
+	build.output += formatDiagnostics(diagnostics) + rest
+	build.status = compile.Status
+	return nil
 }
 
-// compileAndRunTests handles code compilation and test execution
-func (s *server) compileAndRunTests(code string, testCases []TestCase, tmpDir string) ([]*pb.TestResult, error) {
-	srcFile := filepath.Join(tmpDir, "solution.c")
-	if err := os.WriteFile(srcFile, []byte(code), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write source file: %v", err)
+// runTest runs the compiled program for one test case in a fresh working
+// directory holding the test's fixture files, and grades the outcome
+func (s *server) runTest(ctx context.Context, tc TestCase, build *buildResult, dir string, limits Limits, checkers checkerOptions) (*pb.TestResult, error) {
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return nil, err
+	}
+	for name, content := range tc.Files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to create fixture %s: %v", name, err)
+		}
 	}
 
-	outFile := filepath.Join(tmpDir, "solution")
-	cmd := exec.Command("gcc", "-o", outFile, srcFile, "-Wall", "-Werror")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return nil, fmt.Errorf("compilation failed:\n%s", string(output))
+	env := append(defaultEnv(dir), checkers.env(limits)...)
+	for _, name := range sortedKeys(tc.Env) {
+		env = append(env, name+"="+tc.Env[name])
 	}
 
-	var results []*pb.TestResult
-	for _, tc := range testCases {
-		cmd := exec.Command(outFile)
-		cmd.Stdin = strings.NewReader(tc.Input)
-		output, err := cmd.CombinedOutput()
-
-		passed := err == nil && strings.TrimSpace(string(output)) == strings.TrimSpace(tc.Expected)
-		results = append(results, &pb.TestResult{
-			Passed:              passed,
-			TestCaseDescription: tc.Description,
-			ActualOutput:        string(output),
-			ExpectedOutput:      tc.Expected,
-		})
+	binary := build.binaries[tc.Harness]
+	spec := RunSpec{
+		Path:   binary,
+		Args:   tc.Args,
+		Dir:    dir,
+		Stdin:  tc.Input,
+		Env:    env,
+		Limits: limits,
+		Binds:  []string{filepath.Dir(binary)},
+
+		NoAddressSpaceLimit: checkers.active(),
+	}
+	// Harnesses report on a pipe: a results file in the working directory
+	// would be the student's to forge.
+	var results *outputPipe
+	var token string
+	if tc.Harness != "" {
+		var err error
+		if token, err = newHarnessToken(); err != nil {
+			return nil, err
+		}
+		if results, err = newOutputPipe(limits.OutputKB * 1024); err != nil {
+			return nil, err
+		}
+		spec.ExtraFiles = append(spec.ExtraFiles, results.w)
+		spec.Env = append(spec.Env,
+			fmt.Sprintf("%s=%d", harnessResultsEnv, 2+len(spec.ExtraFiles)),
+			harnessTokenEnv+"="+token)
+	}
+	var valgrindXML *outputPipe
+	if checkers.valgrind != "" {
+		var err error
+		if valgrindXML, err = newOutputPipe(maxValgrindReportBytes); err != nil {
+			if results != nil {
+				results.close()
+			}
+			return nil, err
+		}
+		spec = valgrindSpec(checkers.valgrind, spec, valgrindXML.w)
+	}
+	run, err := s.sandbox.Run(ctx, spec)
+	var harness *harnessReport
+	if results != nil {
+		harness = parseHarnessReport(results.close(), token)
+	}
+	var valgrindReport []byte
+	if valgrindXML != nil {
+		valgrindReport = valgrindXML.close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var report sanitizerReport
+	var stoppedBy *pb.SanitizerFinding
+	if checkers.active() {
+		report = parseSanitizerOutput(run.Stderr, build.dir)
+		stoppedBy = gradeSanitized(run, report)
+	}
+	if valgrindXML != nil {
+		report.findings = append(report.findings, parseValgrindReport(valgrindReport, build.dir)...)
+	}
+
+	status, mismatch := gradeRun(tc, run, dir, limits.OutputKB*1024, harness)
+	switch {
+	case stoppedBy != nil:
+		status, mismatch = pb.TestStatus_TEST_STATUS_SANITIZER_ERROR, describeFinding(stoppedBy)
+	case status == pb.TestStatus_TEST_STATUS_PASSED && checkers.failOnFindings && len(report.findings) > 0:
+		status, mismatch = pb.TestStatus_TEST_STATUS_SANITIZER_ERROR, describeFinding(report.findings[0])
+	}
+
+	result := &pb.TestResult{
+		Passed:              status == pb.TestStatus_TEST_STATUS_PASSED,
+		TestCaseDescription: tc.Description,
+		ActualOutput:        string(run.Stdout),
+		ExpectedOutput:      tc.Expected,
+		Status:              status,
+		ExitCode:            int32(run.ExitCode),
+		DurationMs:          run.Duration.Milliseconds(),
+		ActualStderr:        string(run.Stderr),
+		Mismatch:            mismatch,
+		Assertions:          harness.results(),
+		SanitizerFindings:   report.findings,
+	}
+	if run.Signal != 0 {
+		result.Signal = signalName(run.Signal)
+	}
+	return result, nil
+}
+
+// gradeRun decides the status of a finished test run: the exit status,
+// stdout, stderr, any expected files, read up to maxFile bytes each, and the
+// harness's assertions must all match. For wrong answers it also explains
+// the first difference found.
+func gradeRun(tc TestCase, run *RunResult, dir string, maxFile int64, harness *harnessReport) (pb.TestStatus, string) {
+	switch run.Status {
+	case StatusOK, StatusRuntimeError:
+	case StatusTimeLimit:
+		return pb.TestStatus_TEST_STATUS_TIME_LIMIT_EXCEEDED, ""
+	case StatusMemoryLimit:
+		return pb.TestStatus_TEST_STATUS_MEMORY_LIMIT_EXCEEDED, ""
+	case StatusSignaled:
+		return pb.TestStatus_TEST_STATUS_KILLED_BY_SIGNAL, ""
+	case StatusOutputLimit:
+		return pb.TestStatus_TEST_STATUS_OUTPUT_LIMIT_EXCEEDED, ""
+	default:
+		return pb.TestStatus_TEST_STATUS_RUNTIME_ERROR, ""
+	}
+
+	wantExit := 0
+	if tc.ExpectedExitCode != nil {
+		wantExit = *tc.ExpectedExitCode
+	}
+	if run.ExitCode != wantExit {
+		if wantExit == 0 {
+			return pb.TestStatus_TEST_STATUS_RUNTIME_ERROR, ""
+		}
+		return pb.TestStatus_TEST_STATUS_WRONG_ANSWER,
+			fmt.Sprintf("expected exit status %d, got %d", wantExit, run.ExitCode)
+	}
+
+	if tc.Harness != "" {
+		for _, a := range harness.assertions {
+			if !a.Passed {
+				return pb.TestStatus_TEST_STATUS_WRONG_ANSWER,
+					fmt.Sprintf("%s (line %d): %s", a.Test, a.Line, a.Message)
+			}
+		}
+		switch {
+		case !harness.finished:
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, "the program stopped before the test harness finished"
+		case len(harness.assertions) == 0:
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, "the test harness reported no assertions"
+		}
 	}
 
-	return results, nil
+	// Unit tests usually check behaviour through assertions alone
+	if tc.Harness == "" || tc.Expected != "" {
+		if ok, mismatch := matchOutput(tc, string(run.Stdout)); !ok {
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, mismatch
+		}
+	}
+
+	if tc.ExpectedStderr != nil {
+		ok, mismatch := compareLines(strings.TrimSpace(*tc.ExpectedStderr), strings.TrimSpace(string(run.Stderr)))
+		if !ok {
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, "stderr: " + mismatch
+		}
+	}
+
+	for _, name := range sortedKeys(tc.ExpectedFiles) {
+		data, err := readRunFile(dir, name, maxFile)
+		switch {
+		case errors.Is(err, errNotRegularFile):
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, fmt.Sprintf("expected file %s is not a regular file", name)
+		case errors.Is(err, errFileTooLarge):
+			return pb.TestStatus_TEST_STATUS_OUTPUT_LIMIT_EXCEEDED, fmt.Sprintf("file %s is larger than %d KB", name, maxFile/1024)
+		case err != nil:
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, fmt.Sprintf("expected file %s was not created", name)
+		}
+		ok, mismatch := compareLines(strings.TrimSpace(tc.ExpectedFiles[name]), strings.TrimSpace(string(data)))
+		if !ok {
+			return pb.TestStatus_TEST_STATUS_WRONG_ANSWER, fmt.Sprintf("file %s: %s", name, mismatch)
+		}
+	}
+
+	return pb.TestStatus_TEST_STATUS_PASSED, ""
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// Lesson types accepted in the "type" field of lesson.json. Program lessons
+// grade a whole program by its output; unit lessons link the student's
+// functions against C test harnesses that assert on them directly.
+const (
+	lessonTypeProgram = "program"
+	lessonTypeUnit    = "unit"
+)
+
+const (
+	harnessHeaderName = "clearning_test.h"
+
+	// harnessResultsEnv holds the fd of the pipe a harness reports to, and
+	// harnessTokenEnv the run's secret that the harness repeats in its
+	// completion record.
+	harnessResultsEnv = "CLEARNING_RESULTS_FD"
+	harnessTokenEnv   = "CLEARNING_RESULTS_TOKEN"
+
+	// studentMainName is what the student's main is renamed to when their
+	// code is linked against a harness
+	studentMainName = "clearning_student_main"
+)
+
+//go:embed harness/clearning_test.h
+var harnessHeader []byte
+
+// loadHarnesses reads the harness files named by a lesson's tests from dir
+// in fsys and checks that harnesses are used exactly when the lesson is a unit lesson
+func loadHarnesses(fsys fs.FS, dir, lessonType string, testCases []TestCase) (map[string]string, error) {
+	switch lessonType {
+	case "", lessonTypeProgram:
+		for _, tc := range testCases {
+			if tc.Harness != "" {
+				return nil, fmt.Errorf("test %q: harness is only allowed in unit lessons", tc.Description)
+			}
+		}
+		return nil, nil
+	case lessonTypeUnit:
+	default:
+		return nil, fmt.Errorf("unknown lesson type %q (want %s or %s)", lessonType, lessonTypeProgram, lessonTypeUnit)
+	}
+
+	harnesses := make(map[string]string)
+	for _, tc := range testCases {
+		name := tc.Harness
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("test %q: unit tests need a harness", tc.Description)
+		case path.Base(name) != name || path.Ext(name) != ".c" || name == "solution.c":
+			return nil, fmt.Errorf("test %q: harness %q must be a .c file in the lesson directory", tc.Description, name)
+		}
+		if _, ok := harnesses[name]; ok {
+			continue
+		}
+
+		source, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read harness: %v", err)
+		}
+		harnesses[name] = string(source)
+	}
+	return harnesses, nil
+}
+
+// harnessReport is what a harness reported during one run
+type harnessReport struct {
+	assertions []*pb.AssertionResult
+	finished   bool // the harness's main returned
+}
+
+// results returns the assertions of r, which is nil for program tests
+func (r *harnessReport) results() []*pb.AssertionResult {
+	if r == nil {
+		return nil
+	}
+	return r.assertions
+}
+
+// newHarnessToken returns a fresh secret for one harness run
+func newHarnessToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate harness token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// parseHarnessReport parses what a harness wrote: one
+// "PASS|FAIL <tab> test <tab> line <tab> message" record per assertion, and
+// a final "DONE <tab> token" record once its main returns. Without the
+// right token the harness did not finish, whatever the records say.
+func parseHarnessReport(data []byte, token string) *harnessReport {
+	report := &harnessReport{}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) == 2 && fields[0] == "DONE" && fields[1] == token {
+			report.finished = true
+			continue
+		}
+		if len(fields) != 4 {
+			// A record cut short by a crash, or something the student wrote
+			continue
+		}
+		lineNo, _ := strconv.Atoi(fields[2])
+		report.assertions = append(report.assertions, &pb.AssertionResult{
+			Test:    fields[1],
+			Passed:  fields[0] == "PASS",
+			Line:    int32(lineNo),
+			Message: fields[3],
+		})
+	}
+	return report
+}
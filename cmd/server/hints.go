@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// checkHints validates a lesson's hints and the percentage of the score
+// each one costs
+func checkHints(hints []string, penalty float64) error {
+	for i, hint := range hints {
+		if strings.TrimSpace(hint) == "" {
+			return fmt.Errorf("hint %d is empty", i+1)
+		}
+	}
+	if penalty < 0 || penalty > 100 {
+		return fmt.Errorf("hint_penalty %v must be between 0 and 100", penalty)
+	}
+	if penalty > 0 && len(hints) == 0 {
+		return errors.New("hint_penalty is set but the lesson has no hints")
+	}
+	return nil
+}
+
+// hintsUsed returns how many of lesson's hints userID has revealed.
+// Anonymous users cannot reveal hints.
+func (s *server) hintsUsed(ctx context.Context, userID string, lesson *Lesson) (int32, error) {
+	if userID == "" {
+		return 0, nil
+	}
+	progress, err := s.store.GetProgress(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	// A reload may have taken hints away
+	return min(progress.HintsUsed[lesson.ID], int32(len(lesson.Hints))), nil
+}
+
+// revealHint records that userID revealed the next of lesson's hints and
+// returns how many they have revealed, and whether this call revealed a
+// new one. Once every hint is revealed nothing changes.
+func (s *server) revealHint(ctx context.Context, userID string, lesson *Lesson) (int32, bool, error) {
+	total := int32(len(lesson.Hints))
+	revealed := false
+	progress, err := s.store.UpdateProgress(ctx, userID, func(progress *UserProgress) error {
+		if progress.HintsUsed[lesson.ID] >= total {
+			return nil
+		}
+		if progress.HintsUsed == nil {
+			progress.HintsUsed = make(map[int32]int32)
+		}
+		progress.HintsUsed[lesson.ID]++
+		revealed = true
+		return nil
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to record hint: %v", err)
+	}
+	return min(progress.HintsUsed[lesson.ID], total), revealed, nil
+}
+
+// hintDeduction is the percentage taken off a submission's score for the
+// hints revealed before it
+func hintDeduction(lesson *Lesson, used int32) float32 {
+	return float32(lesson.HintPenalty) * float32(used)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// systemPaths are the host paths visible read-only inside the sandbox: what
+// compilers, the C library and the checkers need to run. Missing ones are
+// skipped, and symlinks such as a merged /bin are recreated as symlinks.
+var systemPaths = []string{
+	"/usr",
+	"/bin",
+	"/sbin",
+	"/lib",
+	"/lib32",
+	"/lib64",
+	"/libx32",
+	"/etc/alternatives",
+	"/etc/ld.so.cache",
+	"/etc/ld.so.conf",
+	"/etc/ld.so.conf.d",
+	"/etc/localtime",
+}
+
+// devices are bound from the host into the sandbox's private /dev
+var devices = []string{"null", "zero", "full", "random", "urandom"}
+
+// sandboxTmpSize bounds the private /tmp, which lives in memory
+const sandboxTmpSize = "64m"
+
+// enter builds a fresh root for the program in the helper's own mount
+// namespace and pivots into it: system paths are read-only, /tmp, /dev and
+// /proc are private, and only the run's directories are writable.
+func (c *isolationConfig) enter() error {
+	// Keep every mount below private to this namespace.
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %v", err)
+	}
+	if err := unix.Mount("tmpfs", c.Root, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size=1m,mode=0755"); err != nil {
+		return fmt.Errorf("failed to mount sandbox root: %v", err)
+	}
+	for _, p := range systemPaths {
+		if err := bindSystemPath(c.Root, p); err != nil {
+			return err
+		}
+	}
+
+	tmp := filepath.Join(c.Root, "tmp")
+	if err := os.Mkdir(tmp, 0755); err != nil {
+		return err
+	}
+	if err := unix.Mount("tmpfs", tmp, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size="+sandboxTmpSize+",mode=1777"); err != nil {
+		return fmt.Errorf("failed to mount /tmp: %v", err)
+	}
+	if err := makeDev(filepath.Join(c.Root, "dev")); err != nil {
+		return err
+	}
+	proc := filepath.Join(c.Root, "proc")
+	if err := os.Mkdir(proc, 0755); err != nil {
+		return err
+	}
+	if err := unix.Mount("proc", proc, "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("failed to mount /proc: %v", err)
+	}
+
+	for _, dir := range workDirs(append([]string{c.Dir}, c.Binds...)) {
+		target := filepath.Join(c.Root, dir)
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return err
+		}
+		if err := unix.Mount(dir, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return fmt.Errorf("failed to bind %s: %v", dir, err)
+		}
+		if err := remount(target, unix.MS_NOSUID|unix.MS_NODEV); err != nil {
+			return err
+		}
+	}
+
+	// Swap roots and drop the old one, so nothing else on the host is reachable.
+	old := filepath.Join(c.Root, ".old")
+	if err := os.Mkdir(old, 0700); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(c.Root, old); err != nil {
+		return fmt.Errorf("failed to pivot into sandbox root: %v", err)
+	}
+	if err := unix.Chdir("/"); err != nil {
+		return err
+	}
+	if err := unix.Unmount("/.old", unix.MNT_DETACH); err != nil {
+		return fmt.Errorf("failed to detach host root: %v", err)
+	}
+	if err := os.Remove("/.old"); err != nil {
+		return err
+	}
+	if err := remount("/", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV); err != nil {
+		return err
+	}
+	return unix.Chdir(c.Dir)
+}
+
+// dropPrivileges gives up everything the helper needed to build the root:
+// it switches to c.User, or drops all capabilities when it stays root of a
+// user namespace, and forbids regaining privileges through exec.
+func (c *isolationConfig) dropPrivileges() error {
+	for capability := 0; ; capability++ {
+		err := unix.Prctl(unix.PR_CAPBSET_DROP, uintptr(capability), 0, 0, 0)
+		if err == unix.EINVAL {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop capability %d: %v", capability, err)
+		}
+	}
+	if c.User != 0 {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("failed to clear groups: %v", err)
+		}
+		if err := syscall.Setgid(c.User); err != nil {
+			return fmt.Errorf("failed to switch group: %v", err)
+		}
+		if err := syscall.Setuid(c.User); err != nil {
+			return fmt.Errorf("failed to switch user: %v", err)
+		}
+		// Changing credentials clears the parent death signal.
+		if err := unix.Prctl(unix.PR_SET_PDEATHSIG, uintptr(unix.SIGKILL), 0, 0, 0); err != nil {
+			return err
+		}
+	}
+	header := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	if err := unix.Capset(&header, &data[0]); err != nil {
+		return fmt.Errorf("failed to drop capabilities: %v", err)
+	}
+	return unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0)
+}
+
+// bindSystemPath makes the host path p visible read-only below root
+func bindSystemPath(root, p string) error {
+	info, err := os.Lstat(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(root, p)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	case info.IsDir():
+		err = os.Mkdir(target, 0755)
+	default:
+		err = os.WriteFile(target, nil, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	if err := unix.Mount(p, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to bind %s: %v", p, err)
+	}
+	return remount(target, unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV)
+}
+
+// makeDev mounts a private /dev at dir holding only harmless devices
+func makeDev(dir string) error {
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return err
+	}
+	if err := unix.Mount("tmpfs", dir, "tmpfs", unix.MS_NOSUID|unix.MS_NOEXEC, "size=64k,mode=0755"); err != nil {
+		return fmt.Errorf("failed to mount /dev: %v", err)
+	}
+	for _, name := range devices {
+		target := filepath.Join(dir, name)
+		if err := os.WriteFile(target, nil, 0666); err != nil {
+			return err
+		}
+		if err := unix.Mount("/dev/"+name, target, "", unix.MS_BIND, ""); err != nil {
+			return fmt.Errorf("failed to bind /dev/%s: %v", name, err)
+		}
+	}
+	links := map[string]string{
+		"fd":     "/proc/self/fd",
+		"stdin":  "/proc/self/fd/0",
+		"stdout": "/proc/self/fd/1",
+		"stderr": "/proc/self/fd/2",
+	}
+	for name, link := range links {
+		if err := os.Symlink(link, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return remount(dir, unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NOEXEC)
+}
+
+// remount changes the flags of the mount at target. Flags the kernel locks
+// on mounts inherited by a user namespace are kept, or the call would fail.
+func remount(target string, flags uintptr) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(target, &st); err != nil {
+		return err
+	}
+	kept := []struct {
+		st int64
+		ms uintptr
+	}{
+		{unix.ST_RDONLY, unix.MS_RDONLY},
+		{unix.ST_NOSUID, unix.MS_NOSUID},
+		{unix.ST_NODEV, unix.MS_NODEV},
+		{unix.ST_NOEXEC, unix.MS_NOEXEC},
+		{unix.ST_NOATIME, unix.MS_NOATIME},
+		{unix.ST_NODIRATIME, unix.MS_NODIRATIME},
+		{unix.ST_RELATIME, unix.MS_RELATIME},
+	}
+	for _, f := range kept {
+		if int64(st.Flags)&f.st != 0 {
+			flags |= f.ms
+		}
+	}
+	if err := unix.Mount("", target, "", unix.MS_BIND|unix.MS_REMOUNT|flags, ""); err != nil {
+		return fmt.Errorf("failed to remount %s: %v", target, err)
+	}
+	return nil
+}
+
+// workDirs returns the non-empty dirs sorted, leaving out those already
+// inside another
+func workDirs(dirs []string) []string {
+	sorted := append([]string(nil), dirs...)
+	sort.Strings(sorted)
+
+	var out []string
+next:
+	for _, dir := range sorted {
+		if dir == "" {
+			continue
+		}
+		for _, parent := range out {
+			if dir == parent || strings.HasPrefix(dir, parent+"/") {
+				continue next
+			}
+		}
+		out = append(out, dir)
+	}
+	return out
+}
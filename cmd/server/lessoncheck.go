@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// checkLessons loads the lessons the way the server does, checks that
+// they form a sound curriculum and that every lesson's example.c, and
+// solution.c when it has one, passes its own tests, and writes a report of
+// what it found to w. It returns whether every check passed.
+func checkLessons(sandbox Sandbox, sources []lessonSource, w io.Writer) bool {
+	s := &server{
+		sources:  sources,
+		sandbox:  sandbox,
+		store:    newMemoryStore(),
+		queue:    newGradingQueue(runtime.NumCPU(), 0, 0),
+		freeRoam: true,
+	}
+	s.valgrind, _ = exec.LookPath("valgrind")
+
+	lessons, err := s.readLessons()
+	if err != nil {
+		fmt.Fprintf(w, "✗ lessons failed to load: %v\n", err)
+		return false
+	}
+	s.lessons = lessons
+
+	ok := true
+	problems := curriculumProblems(lessons)
+	for _, problem := range problems {
+		fmt.Fprintf(w, "✗ %s\n", problem)
+		ok = false
+	}
+
+	for _, lesson := range s.sortedLessons() {
+		sources := map[string]string{"example.c": lesson.ExampleCode}
+		if lesson.ReferenceSolution != "" {
+			sources[solutionFile] = lesson.ReferenceSolution
+		}
+		for _, name := range sortedKeys(sources) {
+			failures, err := s.gradeSource(lesson, name, sources[name])
+			switch {
+			case err != nil:
+				fmt.Fprintf(w, "✗ lesson %d (%s): %v\n", lesson.ID, lesson.Dir, err)
+				ok = false
+			case len(failures) > 0:
+				fmt.Fprintf(w, "✗ lesson %d (%s): %s fails %d of %d tests\n",
+					lesson.ID, lesson.Dir, name, len(failures), len(lesson.TestCases))
+				for _, failure := range failures {
+					fmt.Fprintf(w, "    %s\n", failure)
+				}
+				ok = false
+			default:
+				fmt.Fprintf(w, "✓ lesson %d (%s): %s passes %d tests\n", lesson.ID, lesson.Dir, name, len(lesson.TestCases))
+			}
+		}
+	}
+
+	if ok {
+		fmt.Fprintf(w, "\nAll %d lessons are OK\n", len(lessons))
+	} else {
+		fmt.Fprintf(w, "\nFound problems in the lessons above\n")
+	}
+	return ok
+}
+
+// gradeSource grades code, one of a lesson's own sources called name, as
+// the solution and describes each test it fails. Hidden tests are graded
+// like the others, so that their failures can be explained too.
+func (s *server) gradeSource(lesson *Lesson, name, code string) ([]string, error) {
+	req := &pb.CodeSubmission{LessonId: lesson.ID, Code: code}
+	checkers, err := newCheckerOptions(lesson, req, s.valgrind)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := lesson.buildSources(map[string]string{solutionFile: code})
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := makeWorkDir()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	results, build, err := s.compileAndRunTests(context.Background(), sources, lesson, checkers, tmpDir, nil, false)
+	if err != nil {
+		if build != nil {
+			return nil, fmt.Errorf("%s does not compile:\n%s", name, indent(err.Error(), "    "))
+		}
+		return nil, err
+	}
+
+	var failures []string
+	for _, result := range results {
+		if result.Passed {
+			continue
+		}
+		status := strings.TrimPrefix(result.Status.String(), "TEST_STATUS_")
+		failure := fmt.Sprintf("%q: %s", result.TestCaseDescription, strings.ToLower(strings.ReplaceAll(status, "_", " ")))
+		if result.Mismatch != "" {
+			failure += ": " + result.Mismatch
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+// curriculumProblems finds prerequisites that name missing lessons and
+// lessons that can never be unlocked because their prerequisites form a
+// cycle
+func curriculumProblems(lessons map[int32]*Lesson) []string {
+	ids := make([]int32, 0, len(lessons))
+	for id := range lessons {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var problems []string
+	for _, id := range ids {
+		for _, prereq := range lessons[id].Prerequisites {
+			if _, ok := lessons[prereq]; !ok {
+				problems = append(problems, fmt.Sprintf("lesson %d (%s) requires lesson %d, which does not exist",
+					id, lessons[id].Dir, prereq))
+			}
+		}
+	}
+
+	// Depth-first search; a prerequisite still on the stack closes a cycle
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[int32]int)
+	var stack []int32
+	var visit func(id int32)
+	visit = func(id int32) {
+		state[id] = onStack
+		stack = append(stack, id)
+		for _, prereq := range lessons[id].Prerequisites {
+			if _, ok := lessons[prereq]; !ok {
+				continue
+			}
+			switch state[prereq] {
+			case unvisited:
+				visit(prereq)
+			case onStack:
+				problems = append(problems, "prerequisites form a cycle: "+formatCycle(stack, prereq))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return problems
+}
+
+// formatCycle renders the part of stack from start onwards as a cycle, e.g.
+// "lesson 2 requires 3 requires 2"
+func formatCycle(stack []int32, start int32) string {
+	i := len(stack) - 1
+	for i > 0 && stack[i] != start {
+		i--
+	}
+	parts := []string{fmt.Sprintf("lesson %d", stack[i])}
+	for _, id := range stack[i+1:] {
+		parts = append(parts, fmt.Sprint(id))
+	}
+	parts = append(parts, fmt.Sprint(start))
+	return strings.Join(parts, " requires ")
+}
+
+func indent(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/afshin-deriv/c-learning/lessons"
+)
+
+// embeddedLessons names the curriculum compiled into the server
+const embeddedLessons = "embedded"
+
+// defaultLessonsDir is used when no source is given and it exists
+const defaultLessonsDir = "lessons"
+
+// lessonSource is somewhere lessons are loaded from: a directory, a lesson
+// pack or the embedded lessons
+type lessonSource struct {
+	name string // as given with -lessons
+	open func() (fs.FS, error)
+	file string // file or directory to watch for changes; empty if it never changes
+}
+
+// location names a path inside the source in messages and Lesson.Dir
+func (src lessonSource) location(p string) string {
+	return path.Join(filepath.ToSlash(src.name), p)
+}
+
+// lessonSourceFlag collects the -lessons flags in order
+type lessonSourceFlag []string
+
+func (f *lessonSourceFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *lessonSourceFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// sources opens the sources named by the flags, falling back to the
+// lessons directory, or to the embedded lessons if there is none
+func (f lessonSourceFlag) sources() ([]lessonSource, error) {
+	specs := []string(f)
+	if len(specs) == 0 {
+		specs = []string{embeddedLessons}
+		if info, err := os.Stat(defaultLessonsDir); err == nil && info.IsDir() {
+			specs = []string{defaultLessonsDir}
+		}
+	}
+
+	var sources []lessonSource
+	for _, spec := range specs {
+		src, err := newLessonSource(spec)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, src)
+	}
+	return sources, nil
+}
+
+// newLessonSource interprets a -lessons value
+func newLessonSource(spec string) (lessonSource, error) {
+	if spec == embeddedLessons {
+		return lessonSource{
+			name: spec,
+			open: func() (fs.FS, error) { return lessons.FS, nil },
+		}, nil
+	}
+
+	info, err := os.Stat(spec)
+	if err != nil {
+		return lessonSource{}, err
+	}
+	src := lessonSource{name: spec, file: spec}
+	switch {
+	case info.IsDir():
+		src.open = func() (fs.FS, error) { return os.DirFS(spec), nil }
+	case strings.HasSuffix(spec, ".zip"):
+		src.open = func() (fs.FS, error) { return readZipPack(spec) }
+	case strings.HasSuffix(spec, ".tar.gz") || strings.HasSuffix(spec, ".tgz"):
+		src.open = func() (fs.FS, error) { return readTarPack(spec) }
+	default:
+		return lessonSource{}, fmt.Errorf("%s is not a directory, .zip or .tar.gz file", spec)
+	}
+	return src, nil
+}
+
+// readZipPack reads a zip lesson pack into memory. Packs are laid out like
+// the lessons directory, with the track directories at the top.
+func readZipPack(name string) (fs.FS, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range r.File {
+		if _, err := packPath(f.Name); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// readTarPack reads a gzipped tar lesson pack into memory, repacked as an
+// uncompressed zip archive so that it is served like a zip pack
+func readTarPack(name string) (fs.FS, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		p, err := packPath(hdr.Name)
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: p, Method: zip.Store, Modified: hdr.ModTime})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(w, tr); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", hdr.Name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+}
+
+// packPath cleans the name of a file in a lesson pack, rejecting paths that
+// would escape it
+func packPath(name string) (string, error) {
+	p := path.Clean(strings.TrimPrefix(name, "./"))
+	if !fs.ValidPath(p) {
+		return "", fmt.Errorf("invalid path %q in lesson pack", name)
+	}
+	return p, nil
+}
@@ -3,11 +3,24 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"expvar"
+	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"net"
+	"net/http"
 	"os"
-	"path/filepath"
+	"os/exec"
+	"os/signal"
+	"path"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	pb "github.com/afshin-deriv/c-learning/proto"
 	"google.golang.org/grpc"
@@ -15,80 +28,201 @@ import (
 
 type server struct {
 	pb.UnimplementedLearningServiceServer
-	lessons      map[int32]*Lesson
-	userProgress map[string]*UserProgress
+	sandbox Sandbox
+	store   Store
+	queue   *gradingQueue
+	cache   *buildCache // nil when caching is disabled
+
+	valgrind string // path to valgrind, empty when it is not installed
+	freeRoam bool   // whether prerequisites are ignored
+
+	// sources are where lessons are loaded from, in order of precedence;
+	// lessons is replaced as a whole when the lessons are reloaded; use
+	// lesson and allLessons to read it
+	sources []lessonSource
+	mu      sync.RWMutex
+	lessons map[int32]*Lesson
 }
 
 type Lesson struct {
-	ID                 int32      `json:"id"`
-	Title              string     `json:"title"`
-	Description        string     `json:"description"`
-	ExampleCode        string     `json:"example_code"`
-	LearningObjectives []string   `json:"learning_objectives"`
-	TestCases          []TestCase `json:"test_cases"`
-	Prerequisites      []int32    `json:"prerequisites"`
+	ID                 int32       `json:"id"`
+	Title              string      `json:"title"`
+	Description        string      `json:"description"`
+	ExampleCode        string      `json:"example_code"`
+	ReferenceSolution  string      `json:"reference_solution"` // empty when the lesson ships no solution.c
+	LearningObjectives []string    `json:"learning_objectives"`
+	TestCases          []TestCase  `json:"test_cases"`
+	Prerequisites      []int32     `json:"prerequisites"`
+	Limits             Limits      `json:"limits"`
+	Track              string      `json:"track"`
+	Order              int         `json:"order"`
+	Type               string      `json:"type"`
+	Build              BuildConfig `json:"build"`
+	PassThreshold      float64     `json:"pass_threshold"`
+	Hints              []string    `json:"hints"`
+	HintPenalty        float64     `json:"hint_penalty"`
+
+	// SolutionAfterFailures is how many failed attempts reveal the
+	// reference solution to a user who has not completed the lesson
+	SolutionAfterFailures int `json:"solution_after_failures"`
+
+	// ProvidedFiles are compiled with every submission, by path
+	ProvidedFiles map[string]string `json:"-"`
+
+	Sanitizers              []string `json:"sanitizers"`
+	MemoryChecker           string   `json:"memory_checker"`
+	FailOnSanitizerFindings bool     `json:"fail_on_sanitizer_findings"`
+
+	// Harnesses holds the source of each unit-test harness, by file name
+	Harnesses map[string]string `json:"-"`
+
+	// Dir is where the lesson was loaded from
+	Dir string `json:"-"`
 }
 
 type LessonContent struct {
-	ID                 int32    `json:"id"`
-	Title              string   `json:"title"`
-	Description        string   `json:"description"`
-	LearningObjectives []string `json:"learning_objectives"`
-	Prerequisites      []int32  `json:"prerequisites"`
+	ID                 int32       `json:"id"`
+	Title              string      `json:"title"`
+	Description        string      `json:"description"`
+	LearningObjectives []string    `json:"learning_objectives"`
+	Prerequisites      []int32     `json:"prerequisites"`
+	Limits             Limits      `json:"limits"`
+	Track              string      `json:"track"`          // defaults to the top-level directory under lessons
+	Order              int         `json:"order"`          // position within the curriculum, defaults to the ID
+	Type               string      `json:"type"`           // "program" (default) or "unit", see harness.go
+	Build              BuildConfig `json:"build"`          // see compiler.go
+	ProvidedFiles      []string    `json:"provided_files"` // read-only sources in the lesson directory
+	PassThreshold      float64     `json:"pass_threshold"` // percentage of points to complete the lesson, defaults to 100
+	Hints              []string    `json:"hints"`          // revealed one at a time by GetHint, see hints.go
+	HintPenalty        float64     `json:"hint_penalty"`   // percentage of the score deducted per hint revealed
+
+	// Failed attempts after which the reference solution, solution.c, is
+	// revealed without completing the lesson; 0 for never. See solutions.go.
+	SolutionAfterFailures int `json:"solution_after_failures"`
+
+	// Sanitizers every submission is built with, see sanitizers.go, and
+	// whether tests run under valgrind, see valgrind.go. The lesson can fail
+	// on anything either of them finds.
+	Sanitizers              []string `json:"sanitizers"`
+	MemoryChecker           string   `json:"memory_checker"`
+	FailOnSanitizerFindings bool     `json:"fail_on_sanitizer_findings"`
 }
 
 type TestCase struct {
-	Input       string `json:"input"`
-	Expected    string `json:"expected_output"`
-	Description string `json:"description"`
+	Input       string  `json:"input"`
+	Expected    string  `json:"expected_output"`
+	Description string  `json:"description"`
+	Matcher     string  `json:"matcher"`   // see matchers.go; defaults to "trimmed"
+	Tolerance   float64 `json:"tolerance"` // for the numeric matcher
+
+	Args             []string          `json:"args"`
+	Env              map[string]string `json:"env"`
+	Files            map[string]string `json:"files"` // fixtures written to the working directory
+	ExpectedExitCode *int              `json:"expected_exit_code"`
+	ExpectedStderr   *string           `json:"expected_stderr"` // stderr is not checked when unset
+	ExpectedFiles    map[string]string `json:"expected_files"`
+
+	Harness string `json:"harness"` // C file in the lesson directory; unit lessons only
+
+	Hidden bool `json:"hidden"` // left out of GetLesson, with results reported without output
+	Points *int `json:"points"` // towards the lesson's score; defaults to 1
+
+	pattern *regexp.Regexp // compiled expected_output for the regex matcher
 }
 
 type UserProgress struct {
-	CurrentLesson    int32   `json:"current_lesson"`
-	CompletedLessons []int32 `json:"completed_lessons"`
+	CurrentLesson    int32           `json:"current_lesson"`
+	CompletedLessons []int32         `json:"completed_lessons"`
+	HintsUsed        map[int32]int32 `json:"hints_used,omitempty"` // hints revealed, by lesson
 }
 
-func NewServer() *server {
+func NewServer(sandbox Sandbox, store Store, queue *gradingQueue, cache *buildCache, sources []lessonSource, freeRoam bool) *server {
 	s := &server{
-		lessons:      make(map[int32]*Lesson),
-		userProgress: make(map[string]*UserProgress),
+		sources:  sources,
+		sandbox:  sandbox,
+		store:    store,
+		queue:    queue,
+		cache:    cache,
+		freeRoam: freeRoam,
 	}
-	if err := s.loadLessons(); err != nil {
+	s.valgrind, _ = exec.LookPath("valgrind")
+	lessons, err := s.readLessons()
+	if err != nil {
 		log.Fatalf("Failed to load lessons: %v", err)
 	}
+	s.lessons = lessons
+	for _, lesson := range s.sortedLessons() {
+		log.Printf("Loaded lesson %d: %s", lesson.ID, lesson.Title)
+	}
+	for _, problem := range curriculumProblems(lessons) {
+		log.Printf("Warning: %s; run with -check-lessons for details", problem)
+	}
 	return s
 }
 
-func (s *server) loadLessons() error {
-	lessonsPath := "lessons"
-	return filepath.Walk(lessonsPath, func(path string, info os.FileInfo, err error) error {
+// readLessons loads the lessons from every source, failing if any of them
+// is invalid. Sources are merged in order: a lesson from a later source
+// replaces the one with the same ID from an earlier source.
+func (s *server) readLessons() (map[int32]*Lesson, error) {
+	lessons := make(map[int32]*Lesson)
+	for _, src := range s.sources {
+		found, err := s.readSource(src)
+		if err != nil {
+			return nil, err
+		}
+		for id, lesson := range found {
+			if other, ok := lessons[id]; ok {
+				log.Printf("Lesson %d from %s replaces the one from %s", id, lesson.Dir, other.Dir)
+			}
+			lessons[id] = lesson
+		}
+	}
+	return lessons, nil
+}
+
+// readSource loads every lesson in src, one directory with a lesson.json
+// each
+func (s *server) readSource(src lessonSource) (map[int32]*Lesson, error) {
+	fsys, err := src.open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lessons %s: %v", src.name, err)
+	}
+
+	lessons := make(map[int32]*Lesson)
+	err = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
-			return fmt.Errorf("error accessing path %q: %v", path, err)
+			return fmt.Errorf("error accessing path %q: %v", src.location(p), err)
 		}
 
-		if info.IsDir() || filepath.Base(path) != "lesson.json" {
+		if d.IsDir() || path.Base(p) != "lesson.json" {
 			return nil
 		}
+		dir := path.Dir(p)
 
 		// Read and parse lesson.json
-		lessonData, err := os.ReadFile(path)
+		lessonData, err := fs.ReadFile(fsys, p)
 		if err != nil {
-			return fmt.Errorf("failed to read lesson file %s: %v", path, err)
+			return fmt.Errorf("failed to read lesson file %s: %v", src.location(p), err)
 		}
 
 		var lessonContent LessonContent
 		if err := json.Unmarshal(lessonData, &lessonContent); err != nil {
-			return fmt.Errorf("failed to parse lesson file %s: %v", path, err)
+			return fmt.Errorf("failed to parse lesson file %s: %v", src.location(p), err)
 		}
 
 		// Read example code
-		exampleCode, err := os.ReadFile(filepath.Join(filepath.Dir(path), "example.c"))
+		exampleCode, err := fs.ReadFile(fsys, path.Join(dir, "example.c"))
 		if err != nil {
 			return fmt.Errorf("failed to read example code for lesson %d: %v", lessonContent.ID, err)
 		}
 
+		referenceSolution, err := readReferenceSolution(fsys, dir, lessonContent.SolutionAfterFailures)
+		if err != nil {
+			return fmt.Errorf("invalid reference solution for lesson %d: %v", lessonContent.ID, err)
+		}
+
 		// Read test cases
-		testData, err := os.ReadFile(filepath.Join(filepath.Dir(path), "tests.json"))
+		testData, err := fs.ReadFile(fsys, path.Join(dir, "tests.json"))
 		if err != nil {
 			return fmt.Errorf("failed to read test cases for lesson %d: %v", lessonContent.ID, err)
 		}
@@ -97,6 +231,40 @@ func (s *server) loadLessons() error {
 		if err := json.Unmarshal(testData, &testCases); err != nil {
 			return fmt.Errorf("failed to parse test cases for lesson %d: %v", lessonContent.ID, err)
 		}
+		for i := range testCases {
+			if err := testCases[i].prepare(); err != nil {
+				return fmt.Errorf("invalid test case for lesson %d: %v", lessonContent.ID, err)
+			}
+		}
+
+		harnesses, err := loadHarnesses(fsys, dir, lessonContent.Type, testCases)
+		if err != nil {
+			return fmt.Errorf("invalid unit tests for lesson %d: %v", lessonContent.ID, err)
+		}
+
+		if err := checkCheckers(lessonContent.Sanitizers, lessonContent.MemoryChecker); err != nil {
+			return fmt.Errorf("invalid lesson %d: %v", lessonContent.ID, err)
+		}
+		if err := checkPassThreshold(&lessonContent.PassThreshold); err != nil {
+			return fmt.Errorf("invalid lesson %d: %v", lessonContent.ID, err)
+		}
+		if err := checkHints(lessonContent.Hints, lessonContent.HintPenalty); err != nil {
+			return fmt.Errorf("invalid lesson %d: %v", lessonContent.ID, err)
+		}
+		if err := lessonContent.Build.prepare(); err != nil {
+			return fmt.Errorf("invalid build config for lesson %d: %v", lessonContent.ID, err)
+		}
+		if _, err := exec.LookPath(lessonContent.Build.Compiler); err != nil {
+			log.Printf("%s is not installed; submissions to lesson %d will fail to compile", lessonContent.Build.Compiler, lessonContent.ID)
+		}
+		if lessonContent.MemoryChecker == memoryCheckerValgrind && s.valgrind == "" {
+			log.Printf("valgrind is not installed; lesson %d runs without memcheck", lessonContent.ID)
+		}
+
+		providedFiles, err := loadProvidedFiles(fsys, dir, lessonContent.ProvidedFiles)
+		if err != nil {
+			return fmt.Errorf("invalid provided files for lesson %d: %v", lessonContent.ID, err)
+		}
 
 		// Create complete lesson
 		lesson := &Lesson{
@@ -104,22 +272,85 @@ func (s *server) loadLessons() error {
 			Title:              lessonContent.Title,
 			Description:        lessonContent.Description,
 			ExampleCode:        string(exampleCode),
+			ReferenceSolution:  referenceSolution,
 			LearningObjectives: lessonContent.LearningObjectives,
 			TestCases:          testCases,
 			Prerequisites:      lessonContent.Prerequisites,
+			Limits:             lessonContent.Limits.withDefaults(defaultRunLimits),
+			Track:              lessonContent.Track,
+			Order:              lessonContent.Order,
+			Type:               lessonContent.Type,
+			Build:              lessonContent.Build,
+			PassThreshold:      lessonContent.PassThreshold,
+			Hints:              lessonContent.Hints,
+			HintPenalty:        lessonContent.HintPenalty,
+			ProvidedFiles:      providedFiles,
+			Harnesses:          harnesses,
+			Dir:                src.location(dir),
+
+			SolutionAfterFailures: lessonContent.SolutionAfterFailures,
+
+			Sanitizers:              lessonContent.Sanitizers,
+			MemoryChecker:           lessonContent.MemoryChecker,
+			FailOnSanitizerFindings: lessonContent.FailOnSanitizerFindings,
+		}
+		if lesson.Track == "" {
+			lesson.Track = trackFromPath(p)
+		}
+		if lesson.Order == 0 {
+			lesson.Order = int(lesson.ID)
+		}
+		if lesson.Type == "" {
+			lesson.Type = lessonTypeProgram
 		}
 
-		s.lessons[lesson.ID] = lesson
-		log.Printf("Loaded lesson %d: %s", lesson.ID, lesson.Title)
+		if other, ok := lessons[lesson.ID]; ok {
+			return fmt.Errorf("lesson %d is defined in both %s and %s", lesson.ID, other.Dir, lesson.Dir)
+		}
+		lessons[lesson.ID] = lesson
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
+
+// lesson returns the lesson with the given ID from the current lesson set
+func (s *server) lesson(id int32) (*Lesson, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	lesson, ok := s.lessons[id]
+	return lesson, ok
+}
+
+// allLessons returns the current lesson set. It must not be modified; a
+// reload replaces it rather than changing it.
+func (s *server) allLessons() map[int32]*Lesson {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lessons
+}
+
+// trackFromPath derives a lesson's track from the top-level directory of
+// its source, e.g. fundamentals/02_variables/lesson.json is "fundamentals"
+func trackFromPath(p string) string {
+	parts := strings.Split(p, "/")
+	if len(parts) < 3 {
+		return defaultTrack
+	}
+	return parts[0]
 }
 
 func (s *server) GetLesson(ctx context.Context, req *pb.LessonRequest) (*pb.LessonResponse, error) {
-	lesson, ok := s.lessons[req.LessonId]
+	lesson, ok := s.lesson(req.LessonId)
 	if !ok {
-		return nil, fmt.Errorf("lesson %d not found", req.LessonId)
+		return nil, lessonNotFound(req.LessonId)
+	}
+	if err := s.checkPrerequisites(ctx, requestUserID(ctx, req.UserId), lesson); err != nil {
+		return nil, err
 	}
+	visible := visibleTestCases(lesson.TestCases)
 
 	return &pb.LessonResponse{
 		LessonId:           lesson.ID,
@@ -127,28 +358,105 @@ func (s *server) GetLesson(ctx context.Context, req *pb.LessonRequest) (*pb.Less
 		Description:        lesson.Description,
 		ExampleCode:        lesson.ExampleCode,
 		LearningObjectives: lesson.LearningObjectives,
-		TestCases:          convertTestCases(lesson.TestCases),
+		TestCases:          convertTestCases(visible),
+		Build:              lesson.Build.proto(),
+		ProvidedFiles:      convertSourceFiles(lesson.ProvidedFiles),
+		HiddenTestCount:    int32(len(lesson.TestCases) - len(visible)),
+		PassThreshold:      float32(lesson.PassThreshold),
+		HintCount:          int32(len(lesson.Hints)),
+		HintPenalty:        float32(lesson.HintPenalty),
+
+		HasSolution:           lesson.ReferenceSolution != "",
+		SolutionAfterFailures: int32(lesson.SolutionAfterFailures),
 	}, nil
 }
 
 func (s *server) ValidateCode(ctx context.Context, req *pb.CodeSubmission) (*pb.ValidationResponse, error) {
-	lesson, ok := s.lessons[req.LessonId]
+	return s.validate(ctx, req, nil)
+}
+
+func (s *server) ValidateCodeStream(req *pb.CodeSubmission, stream pb.LearningService_ValidateCodeStreamServer) error {
+	resp, err := s.validate(stream.Context(), req, stream.Send)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&pb.ValidationEvent{
+		Event: &pb.ValidationEvent_Summary{Summary: resp},
+	})
+}
+
+// validate grades a submission, reporting progress to events when it is
+// not nil, and records the attempt for the submitting user
+func (s *server) validate(ctx context.Context, req *pb.CodeSubmission, events eventSink) (*pb.ValidationResponse, error) {
+	lesson, ok := s.lesson(req.LessonId)
 	if !ok {
-		return nil, fmt.Errorf("lesson %d not found", req.LessonId)
+		return nil, lessonNotFound(req.LessonId)
+	}
+	userID := requestUserID(ctx, req.UserId)
+	if err := s.checkPrerequisites(ctx, userID, lesson); err != nil {
+		return nil, err
+	}
+	checkers, err := newCheckerOptions(lesson, req, s.valgrind)
+	if err != nil {
+		return nil, err
+	}
+	submitted, err := submittedSources(req)
+	if err != nil {
+		return nil, invalidField("files", err)
+	}
+	sources, err := lesson.buildSources(submitted)
+	if err != nil {
+		return nil, invalidField("files", err)
 	}
 
-	// Create temporary directory for compilation
-	tmpDir, err := os.MkdirTemp("", "c-learning-*")
+	// Wait for a free worker, telling streaming clients where they are
+	release, err := s.queue.acquire(ctx, submitterKey(ctx, userID), func(position int) error {
+		return events.send(&pb.ValidationEvent{
+			Event: &pb.ValidationEvent_Queued{Queued: &pb.Queued{Position: int32(position)}},
+		})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, interrupted(ctx, "waiting in the queue")
+		}
+		return nil, err
+	}
+	defer release()
+	if ctx.Err() != nil {
+		return nil, interrupted(ctx, "waiting in the queue")
+	}
+
+	tmpDir, err := makeWorkDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %v", err)
+		return nil, err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	results, err := s.compileAndRunTests(req.Code, lesson.TestCases, tmpDir)
+	results, build, err := s.compileAndRunTests(ctx, sources, lesson, checkers, tmpDir, events, true)
 	if err != nil {
+		// A client that went away or ran out of time is not a failed
+		// attempt.
+		if ctx.Err() != nil {
+			log.Printf("Submission to lesson %d abandoned: %v", lesson.ID, err)
+			return nil, err
+		}
+		// Only code that does not compile is the student's fault; anything
+		// else failed on the server's side and is reported as INTERNAL.
+		if build == nil {
+			return nil, err
+		}
+		if userID != "" {
+			if err := s.recordSubmission(ctx, userID, lesson.ID, submitted, false, 0); err != nil {
+				return nil, err
+			}
+		}
 		return &pb.ValidationResponse{
-			IsValid:  false,
-			Feedback: err.Error(),
+			IsValid:         false,
+			Feedback:        err.Error(),
+			PassThreshold:   float32(lesson.PassThreshold),
+			CompileError:    true,
+			Diagnostics:     build.diagnostics,
+			CompileCommands: build.commands,
 		}, nil
 	}
 
@@ -160,26 +468,64 @@ func (s *server) ValidateCode(ctx context.Context, req *pb.CodeSubmission) (*pb.
 		}
 	}
 
+	points, maxPoints, score := lessonScore(results)
+	canProceed := float64(score) >= lesson.PassThreshold
+	hintsUsed, err := s.hintsUsed(ctx, userID, lesson)
+	if err != nil {
+		return nil, err
+	}
+	deduction := hintDeduction(lesson, hintsUsed)
+	score = max(score-deduction, 0)
+
+	if userID != "" {
+		if err := s.recordSubmission(ctx, userID, lesson.ID, submitted, canProceed, score); err != nil {
+			return nil, err
+		}
+	}
+
 	return &pb.ValidationResponse{
-		IsValid:     allPassed,
-		TestResults: results,
-		Feedback:    getFeedback(allPassed, results),
-		CanProceed:  allPassed,
+		IsValid:         allPassed,
+		TestResults:     results,
+		Feedback:        getFeedback(allPassed, results),
+		CanProceed:      canProceed,
+		Diagnostics:     build.diagnostics,
+		CompileCommands: build.commands,
+		Score:           score,
+		Points:          points,
+		MaxPoints:       maxPoints,
+		PassThreshold:   float32(lesson.PassThreshold),
+		HintsUsed:       hintsUsed,
+		HintDeduction:   deduction,
 	}, nil
 }
 
+// makeWorkDir creates the temporary directory a submission is compiled and
+// tested in
+func makeWorkDir() (string, error) {
+	tmpDir, err := os.MkdirTemp("", "c-learning-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %v", err)
+	}
+	// Each test runs in a directory of its own below it, as a different
+	// sandbox user than the compiler, or with no compile step at all when
+	// the build comes from the cache, and must be able to reach it
+	if err := os.Chmod(tmpDir, 0711); err != nil {
+		os.RemoveAll(tmpDir)
+		return "", fmt.Errorf("failed to create temp directory: %v", err)
+	}
+	return tmpDir, nil
+}
+
 func (s *server) GetProgress(ctx context.Context, req *pb.ProgressRequest) (*pb.ProgressResponse, error) {
-	progress, ok := s.userProgress[req.UserId]
-	if !ok {
-		progress = &UserProgress{
-			CurrentLesson:    1,
-			CompletedLessons: []int32{},
-		}
-		s.userProgress[req.UserId] = progress
+	progress, err := s.store.GetProgress(ctx, requestUserID(ctx, req.UserId))
+	if err != nil {
+		return nil, err
 	}
 
-	totalLessons := len(s.lessons)
-	completionPercentage := float32(len(progress.CompletedLessons)) / float32(totalLessons) * 100
+	var completionPercentage float32
+	if totalLessons := len(s.allLessons()); totalLessons > 0 {
+		completionPercentage = float32(len(progress.CompletedLessons)) / float32(totalLessons) * 100
+	}
 
 	return &pb.ProgressResponse{
 		CurrentLesson:        progress.CurrentLesson,
@@ -188,16 +534,224 @@ func (s *server) GetProgress(ctx context.Context, req *pb.ProgressRequest) (*pb.
 	}, nil
 }
 
+func (s *server) GetNextLesson(ctx context.Context, req *pb.NextLessonRequest) (*pb.NextLessonResponse, error) {
+	lesson, err := s.getNextAvailableLesson(ctx, requestUserID(ctx, req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	if lesson == nil {
+		return &pb.NextLessonResponse{CurriculumCompleted: true}, nil
+	}
+	return &pb.NextLessonResponse{
+		LessonId: lesson.ID,
+		Title:    lesson.Title,
+	}, nil
+}
+
+func (s *server) GetHint(ctx context.Context, req *pb.HintRequest) (*pb.HintResponse, error) {
+	lesson, ok := s.lesson(req.LessonId)
+	if !ok {
+		return nil, lessonNotFound(req.LessonId)
+	}
+	userID := requestUserID(ctx, req.UserId)
+	if userID == "" {
+		return nil, invalidField("user_id", errors.New("hints are counted per user, so a user ID is required"))
+	}
+	if err := s.checkPrerequisites(ctx, userID, lesson); err != nil {
+		return nil, err
+	}
+
+	used, revealed, err := s.revealHint(ctx, userID, lesson)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.HintResponse{
+		Hints:       lesson.Hints[:used],
+		TotalHints:  int32(len(lesson.Hints)),
+		Revealed:    revealed,
+		HintPenalty: float32(lesson.HintPenalty),
+	}, nil
+}
+
+func (s *server) GetSolution(ctx context.Context, req *pb.SolutionRequest) (*pb.SolutionResponse, error) {
+	lesson, ok := s.lesson(req.LessonId)
+	if !ok {
+		return nil, lessonNotFound(req.LessonId)
+	}
+	if lesson.ReferenceSolution == "" {
+		return nil, solutionNotFound(lesson.ID)
+	}
+	userID := requestUserID(ctx, req.UserId)
+	if userID == "" {
+		return nil, invalidField("user_id", errors.New("solutions are unlocked per user, so a user ID is required"))
+	}
+
+	completed, failures, err := s.solutionUnlocked(ctx, userID, lesson)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SolutionResponse{
+		Code:           lesson.ReferenceSolution,
+		Completed:      completed,
+		FailedAttempts: int32(failures),
+	}, nil
+}
+
+func (s *server) ListLessons(ctx context.Context, req *pb.ListLessonsRequest) (*pb.ListLessonsResponse, error) {
+	var progress *UserProgress
+	if userID := requestUserID(ctx, req.UserId); userID != "" {
+		var err error
+		if progress, err = s.store.GetProgress(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.ListLessonsResponse{}
+	tracks := make(map[string]*pb.Track)
+	for _, lesson := range s.sortedLessons() {
+		track, ok := tracks[lesson.Track]
+		if !ok {
+			track = &pb.Track{Name: lesson.Track}
+			tracks[lesson.Track] = track
+			resp.Tracks = append(resp.Tracks, track)
+		}
+
+		summary := &pb.LessonSummary{
+			LessonId:      lesson.ID,
+			Title:         lesson.Title,
+			Prerequisites: lesson.Prerequisites,
+		}
+		if progress != nil {
+			summary.Status = s.lessonStatus(lesson.ID, progress)
+		}
+		track.Lessons = append(track.Lessons, summary)
+	}
+	return resp, nil
+}
+
 func main() {
-	lis, err := net.Listen("tcp", ":50052")
+	// The server binary doubles as the sandbox helper; see sandbox.go.
+	if len(os.Args) > 1 && os.Args[1] == sandboxHelperArg {
+		runSandboxHelper(os.Args[2:])
+		return
+	}
+
+	sandboxKind := flag.String("sandbox", "auto", "Execution sandbox: auto, namespace or rlimit")
+	cgroupRoot := flag.String("cgroup-root", "/sys/fs/cgroup/c-learning", "cgroup v2 directory for sandboxed runs (empty to disable)")
+	addr := flag.String("addr", ":50052", "gRPC listen address")
+	httpAddr := flag.String("http", "", "Listen address for the REST gateway and OpenAPI document (empty to disable)")
+	adminAddr := flag.String("admin", "", "Listen address for server metrics at /debug/vars, not to be exposed publicly (empty to disable)")
+	storeKind := flag.String("store", "bolt", "Progress storage: bolt or memory")
+	dbPath := flag.String("db", "c-learning.db", "Database file for the bolt store")
+	workers := flag.Int("workers", runtime.NumCPU(), "Number of submissions graded at once")
+	queueSize := flag.Int("queue-size", 100, "Number of submissions that may wait for a worker before new ones are rejected")
+	maxPerUser := flag.Int("max-per-user", 2, "Submissions each user, or each address for anonymous ones, may have running or waiting (0 for no limit)")
+	cacheDir := flag.String("build-cache", "build-cache", "Directory caching compiled submissions (empty to disable)")
+	cacheMB := flag.Int64("build-cache-mb", 512, "Size limit of the build cache in megabytes")
+	var lessonSpecs lessonSourceFlag
+	flag.Var(&lessonSpecs, "lessons", `Where to load lessons from: a directory, a .zip or .tar.gz lesson pack, or "embedded" for the lessons built into the server. Repeat to merge several; later sources replace lessons with the same ID (default "lessons", or "embedded" when there is no such directory)`)
+	reloadInterval := flag.Duration("reload-interval", 2*time.Second, "How often to check lesson directories and packs for changes (0 to disable)")
+	freeRoam := flag.Bool("free-roam", false, "Let students open and submit any lesson regardless of prerequisites")
+	checkOnly := flag.Bool("check-lessons", false, "Check the lessons, grading each example.c against its tests, and exit")
+	flag.Parse()
+
+	sandbox, err := newSandbox(*sandboxKind, *cgroupRoot)
+	if err != nil {
+		log.Fatalf("failed to set up sandbox: %v", err)
+	}
+	defer sandbox.Close()
+	log.Printf("Using %s sandbox", sandbox.Name())
+
+	sources, err := lessonSpecs.sources()
+	if err != nil {
+		log.Fatalf("invalid lessons source: %v", err)
+	}
+
+	if *checkOnly {
+		if !checkLessons(sandbox, sources, os.Stdout) {
+			sandbox.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Student programs that can reach the server's files could rewrite
+	// anything it keeps on disk, so nothing persistent is trusted then.
+	if sandbox.ExposesServerFiles() {
+		if *storeKind == "bolt" {
+			log.Fatalf("refusing to keep progress in %s: sandboxed programs run as the server's user and could overwrite it; run the server as root, use the namespace sandbox or use -store memory", *dbPath)
+		}
+		if *cacheDir != "" {
+			log.Printf("Build cache disabled: sandboxed programs run as the server's user and could tamper with cached binaries; run the server as root or use the namespace sandbox to enable it")
+			*cacheDir = ""
+		}
+	}
+
+	store, err := openStore(*storeKind, *dbPath)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	lis, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterLearningServiceServer(s, NewServer())
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryErrorInterceptor),
+		grpc.StreamInterceptor(streamErrorInterceptor),
+	)
+	queue := newGradingQueue(*workers, *queueSize, *maxPerUser)
+	log.Printf("Grading with %d workers", queue.workers)
+
+	var cache *buildCache
+	if *cacheDir != "" {
+		cache, err = openBuildCache(*cacheDir, *cacheMB<<20)
+		if err != nil {
+			log.Fatalf("failed to open build cache: %v", err)
+		}
+		expvar.Publish("build_cache", expvar.Func(cache.stats))
+		log.Printf("Caching builds in %s", *cacheDir)
+	}
+	learning := NewServer(sandbox, store, queue, cache, sources, *freeRoam)
+	if *reloadInterval > 0 {
+		go learning.watchLessons(*reloadInterval)
+	}
+	pb.RegisterLearningServiceServer(s, learning)
+
+	if *httpAddr != "" {
+		gateway, err := newGatewayHandler(context.Background(), *addr)
+		if err != nil {
+			log.Fatalf("failed to set up REST gateway: %v", err)
+		}
+		go func() {
+			log.Printf("REST gateway listening on %s", *httpAddr)
+			if err := http.ListenAndServe(*httpAddr, gateway); err != nil {
+				log.Fatalf("failed to serve REST gateway: %v", err)
+			}
+		}()
+	}
+
+	if *adminAddr != "" {
+		go func() {
+			log.Printf("Admin metrics listening on %s", *adminAddr)
+			if err := http.ListenAndServe(*adminAddr, newAdminHandler()); err != nil {
+				log.Fatalf("failed to serve admin metrics: %v", err)
+			}
+		}()
+	}
+
+	// Stop cleanly on SIGINT or SIGTERM, so the deferred cleanup runs
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stop
+		log.Printf("Shutting down")
+		s.GracefulStop()
+	}()
 
-	log.Printf("Server listening on :50052")
+	log.Printf("Server listening on %s", *addr)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
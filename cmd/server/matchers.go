@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Matcher kinds accepted in the "matcher" field of tests.json
+const (
+	matchTrimmed        = "trimmed" // default: equal after trimming surrounding whitespace
+	matchExact          = "exact"
+	matchWhitespace     = "whitespace" // equal after collapsing all runs of whitespace
+	matchRegex          = "regex"      // expected_output is a pattern the whole output must match
+	matchNumeric        = "numeric"    // numbers may differ by at most tolerance
+	matchUnorderedLines = "unordered_lines"
+	matchContains       = "contains"
+)
+
+// defaultTolerance is used by numeric matching when a test sets none
+const defaultTolerance = 1e-6
+
+// prepare validates a test case loaded from tests.json and compiles its
+// pattern, if any
+func (tc *TestCase) prepare() error {
+	for _, files := range []map[string]string{tc.Files, tc.ExpectedFiles} {
+		for name := range files {
+			if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+				return fmt.Errorf("test %q: file name %q must be a plain name", tc.Description, name)
+			}
+		}
+	}
+
+	if tc.Points != nil && *tc.Points < 0 {
+		return fmt.Errorf("test %q: points must not be negative", tc.Description)
+	}
+
+	switch tc.Matcher {
+	case "":
+		tc.Matcher = matchTrimmed
+	case matchTrimmed, matchExact, matchWhitespace, matchUnorderedLines, matchContains:
+	case matchNumeric:
+		if tc.Tolerance < 0 {
+			return fmt.Errorf("test %q: tolerance must not be negative", tc.Description)
+		}
+		if tc.Tolerance == 0 {
+			tc.Tolerance = defaultTolerance
+		}
+	case matchRegex:
+		re, err := regexp.Compile(`^(?:` + tc.Expected + `)$`)
+		if err != nil {
+			return fmt.Errorf("test %q: invalid pattern: %v", tc.Description, err)
+		}
+		tc.pattern = re
+	default:
+		return fmt.Errorf("test %q: unknown matcher %q", tc.Description, tc.Matcher)
+	}
+	return nil
+}
+
+// matchOutput compares a program's output with the test's expectation and,
+// when they differ, explains the first difference
+func matchOutput(tc TestCase, output string) (bool, string) {
+	switch tc.Matcher {
+	case matchExact:
+		return compareLines(tc.Expected, output)
+	case matchWhitespace:
+		expected, actual := strings.Fields(tc.Expected), strings.Fields(output)
+		if strings.Join(expected, " ") == strings.Join(actual, " ") {
+			return true, ""
+		}
+		return false, firstTokenDifference(expected, actual)
+	case matchRegex:
+		if tc.pattern.MatchString(strings.TrimRight(output, "\n")) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("output does not match the pattern %q", tc.Expected)
+	case matchNumeric:
+		return compareNumeric(tc.Expected, output, tc.Tolerance)
+	case matchUnorderedLines:
+		return compareUnorderedLines(tc.Expected, output)
+	case matchContains:
+		if strings.Contains(output, tc.Expected) {
+			return true, ""
+		}
+		return false, fmt.Sprintf("output does not contain %q", tc.Expected)
+	default:
+		return compareLines(strings.TrimSpace(tc.Expected), strings.TrimSpace(output))
+	}
+}
+
+// compareLines requires exact equality and reports the first differing line
+func compareLines(expected, actual string) (bool, string) {
+	if expected == actual {
+		return true, ""
+	}
+
+	expLines, actLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	for i := 0; i < len(expLines) && i < len(actLines); i++ {
+		if expLines[i] != actLines[i] {
+			return false, fmt.Sprintf("line %d: expected %q, got %q", i+1, expLines[i], actLines[i])
+		}
+	}
+	if len(expLines) != len(actLines) {
+		return false, fmt.Sprintf("expected %d lines, got %d", len(expLines), len(actLines))
+	}
+	return false, "output differs from the expected output"
+}
+
+func firstTokenDifference(expected, actual []string) string {
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		if expected[i] != actual[i] {
+			return fmt.Sprintf("word %d: expected %q, got %q", i+1, expected[i], actual[i])
+		}
+	}
+	return fmt.Sprintf("expected %d words, got %d", len(expected), len(actual))
+}
+
+// compareNumeric compares whitespace-separated tokens, allowing numeric
+// tokens to differ by tolerance and requiring all others to be equal
+func compareNumeric(expected, actual string, tolerance float64) (bool, string) {
+	expTokens, actTokens := strings.Fields(expected), strings.Fields(actual)
+	for i := 0; i < len(expTokens) && i < len(actTokens); i++ {
+		exp, act := expTokens[i], actTokens[i]
+		expNum, expErr := strconv.ParseFloat(exp, 64)
+		if expErr != nil {
+			if exp != act {
+				return false, fmt.Sprintf("word %d: expected %q, got %q", i+1, exp, act)
+			}
+			continue
+		}
+
+		actNum, err := strconv.ParseFloat(act, 64)
+		if err != nil {
+			return false, fmt.Sprintf("word %d: expected a number close to %s, got %q", i+1, exp, act)
+		}
+		if !numbersClose(expNum, actNum, tolerance) {
+			return false, fmt.Sprintf("word %d: expected %s (±%g), got %s", i+1, exp, tolerance, act)
+		}
+	}
+	if len(expTokens) != len(actTokens) {
+		return false, fmt.Sprintf("expected %d words, got %d", len(expTokens), len(actTokens))
+	}
+	return true, ""
+}
+
+// numbersClose reports whether actual is within tolerance of expected. NaN
+// only matches NaN and an infinity only the same infinity, as their
+// difference is NaN and never compares greater than the tolerance.
+func numbersClose(expected, actual, tolerance float64) bool {
+	switch {
+	case math.IsNaN(expected) || math.IsNaN(actual):
+		return math.IsNaN(expected) && math.IsNaN(actual)
+	case math.IsInf(expected, 0) || math.IsInf(actual, 0):
+		return expected == actual
+	}
+	return math.Abs(expected-actual) <= tolerance
+}
+
+// compareUnorderedLines requires the same multiset of non-empty lines,
+// ignoring trailing whitespace on each line
+func compareUnorderedLines(expected, actual string) (bool, string) {
+	counts := make(map[string]int)
+	for _, line := range nonEmptyLines(expected) {
+		counts[line]++
+	}
+
+	var unexpected []string
+	for _, line := range nonEmptyLines(actual) {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			unexpected = append(unexpected, line)
+		}
+	}
+
+	var missing []string
+	for line, n := range counts {
+		for ; n > 0; n-- {
+			missing = append(missing, line)
+		}
+	}
+	sort.Strings(missing)
+
+	switch {
+	case len(missing) > 0:
+		return false, fmt.Sprintf("missing line %q", missing[0])
+	case len(unexpected) > 0:
+		return false, fmt.Sprintf("unexpected line %q", unexpected[0])
+	default:
+		return true, ""
+	}
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
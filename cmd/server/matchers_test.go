@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestCompareNumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		want     bool
+	}{
+		{"equal", "BMI: 23.0", "BMI: 23.0", true},
+		{"within tolerance", "BMI: 23.0", "BMI: 23.04", true},
+		{"outside tolerance", "BMI: 23.0", "BMI: 23.2", false},
+		{"words differ", "BMI: 23.0", "bmi: 23.0", false},
+		{"not a number", "23.0", "twenty-three", false},
+		{"missing word", "1 2 3", "1 2", false},
+		{"nan matches nan", "nan", "NaN", true},
+		{"nan expected", "nan", "23.0", false},
+		{"nan actual", "23.0", "nan", false},
+		{"inf matches inf", "inf", "inf", true},
+		{"inf expected", "inf", "1e308", false},
+		{"inf actual", "1e308", "inf", false},
+		{"opposite infinities", "inf", "-inf", false},
+		{"inf against nan", "inf", "nan", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, why := compareNumeric(tt.expected, tt.actual, 0.05)
+			if got != tt.want {
+				t.Errorf("compareNumeric(%q, %q) = %v (%s), want %v", tt.expected, tt.actual, got, why, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		matcher  string
+		expected string
+		output   string
+		want     bool
+		mismatch string
+	}{
+		{"exact equal", matchExact, "a\nb\n", "a\nb\n", true, ""},
+		{"exact line differs", matchExact, "a\nb\n", "a\nc\n", false, `line 2: expected "b", got "c"`},
+		{"exact missing newline", matchExact, "a\n", "a", false, "expected 2 lines, got 1"},
+		{"exact trailing space", matchExact, "a", "a ", false, `line 1: expected "a", got "a "`},
+		{"trimmed ignores surrounding space", matchTrimmed, "a\n", "  a\n\n", true, ""},
+		{"regex matches", matchRegex, `Sum: \d+`, "Sum: 42\n", true, ""},
+		{"regex must match everything", matchRegex, `Sum: \d+`, "Sum: 42 and more\n", false, `output does not match the pattern "Sum: \\d+"`},
+		{"regex alternatives anchored", matchRegex, `yes|no`, "maybe no\n", false, `output does not match the pattern "yes|no"`},
+		{"unordered same lines", matchUnorderedLines, "b\na\na\n", "a\nb\na\n", true, ""},
+		{"unordered ignores blank lines", matchUnorderedLines, "a\nb", "\nb  \n\na\n", true, ""},
+		{"unordered missing line", matchUnorderedLines, "a\nb\nc\n", "c\na\n", false, `missing line "b"`},
+		{"unordered repeated line", matchUnorderedLines, "a\na\n", "a\n", false, `missing line "a"`},
+		{"unordered extra line", matchUnorderedLines, "a\n", "a\nz\n", false, `unexpected line "z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := TestCase{Description: tt.name, Matcher: tt.matcher, Expected: tt.expected}
+			if err := tc.prepare(); err != nil {
+				t.Fatal(err)
+			}
+			got, mismatch := matchOutput(tc, tt.output)
+			if got != tt.want || mismatch != tt.mismatch {
+				t.Errorf("matchOutput(%q, %q) = %v, %q; want %v, %q", tt.expected, tt.output, got, mismatch, tt.want, tt.mismatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// prerequisiteServer serves three lessons, each requiring the ones before it
+func prerequisiteServer(freeRoam bool) *server {
+	lessons := []*Lesson{
+		{ID: 1, Order: 1},
+		{ID: 2, Order: 2, Prerequisites: []int32{1}},
+		{ID: 3, Order: 3, Prerequisites: []int32{1, 2}},
+	}
+	s := &server{lessons: make(map[int32]*Lesson), store: newMemoryStore(), freeRoam: freeRoam}
+	for _, lesson := range lessons {
+		s.lessons[lesson.ID] = lesson
+	}
+	return s
+}
+
+// completeLessons marks lessons completed for user
+func completeLessons(t *testing.T, s *server, user string, lessons ...int32) {
+	t.Helper()
+	for _, id := range lessons {
+		if err := s.updateProgress(context.Background(), user, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMissingPrerequisites(t *testing.T) {
+	lesson := &Lesson{ID: 3, Prerequisites: []int32{1, 2}}
+	tests := []struct {
+		completed []int32
+		want      []int32
+	}{
+		{nil, []int32{1, 2}},
+		{[]int32{2}, []int32{1}},
+		{[]int32{5, 1}, []int32{2}},
+		{[]int32{2, 1}, nil},
+	}
+	for _, tt := range tests {
+		got := missingPrerequisites(lesson, &UserProgress{CompletedLessons: tt.completed})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("missing with %v completed = %v, want %v", tt.completed, got, tt.want)
+		}
+	}
+}
+
+func TestLessonStatus(t *testing.T) {
+	progress := &UserProgress{CurrentLesson: 2, CompletedLessons: []int32{1}}
+	want := map[int32]pb.LessonStatus{
+		1: pb.LessonStatus_LESSON_STATUS_COMPLETED,
+		2: pb.LessonStatus_LESSON_STATUS_AVAILABLE,
+		3: pb.LessonStatus_LESSON_STATUS_LOCKED,
+	}
+	s := prerequisiteServer(false)
+	for id, wantStatus := range want {
+		if got := s.lessonStatus(id, progress); got != wantStatus {
+			t.Errorf("lesson %d: status %v, want %v", id, got, wantStatus)
+		}
+	}
+
+	s = prerequisiteServer(true)
+	if got := s.lessonStatus(3, progress); got != pb.LessonStatus_LESSON_STATUS_AVAILABLE {
+		t.Errorf("lesson 3 when roaming freely: status %v, want available", got)
+	}
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	ctx := context.Background()
+	s := prerequisiteServer(false)
+	completeLessons(t, s, "alice", 1)
+
+	tests := []struct {
+		user    string
+		lesson  int32
+		missing string // empty when the lesson is open
+	}{
+		{"", 1, ""},
+		{"", 3, "complete lessons 1, 2 first"},
+		{"bob", 2, "complete lesson 1 first"},
+		{"alice", 2, ""},
+		{"alice", 3, "complete lesson 2 first"},
+	}
+	for _, tt := range tests {
+		err := s.checkPrerequisites(ctx, tt.user, s.lessons[tt.lesson])
+		if tt.missing == "" {
+			if err != nil {
+				t.Errorf("user %q, lesson %d: %v", tt.user, tt.lesson, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), tt.missing) {
+			t.Errorf("user %q, lesson %d: error %v, want FAILED_PRECONDITION with %q", tt.user, tt.lesson, err, tt.missing)
+		}
+	}
+
+	s = prerequisiteServer(true)
+	if err := s.checkPrerequisites(ctx, "", s.lessons[3]); err != nil {
+		t.Errorf("lesson 3 when roaming freely: %v", err)
+	}
+}
+
+func TestNextAvailableLesson(t *testing.T) {
+	ctx := context.Background()
+	s := prerequisiteServer(false)
+
+	for _, want := range []int32{1, 2, 3} {
+		next, err := s.getNextAvailableLesson(ctx, "alice")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next == nil || next.ID != want {
+			t.Fatalf("next lesson = %v, want lesson %d", next, want)
+		}
+		completeLessons(t, s, "alice", want)
+	}
+	if next, err := s.getNextAvailableLesson(ctx, "alice"); next != nil || err != nil {
+		t.Errorf("next lesson after completing all = %v, %v; want none", next, err)
+	}
+
+	// A lesson whose prerequisite does not exist can never be started.
+	s.lessons[4] = &Lesson{ID: 4, Order: 4, Prerequisites: []int32{99}}
+	if _, err := s.getNextAvailableLesson(ctx, "alice"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("unreachable lesson: error %v, want FAILED_PRECONDITION", err)
+	}
+}
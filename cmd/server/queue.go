@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/peer"
+)
+
+// queueRetryDelay is how long clients turned away by a full queue are
+// asked to wait before resubmitting
+const queueRetryDelay = 5 * time.Second
+
+// gradingQueue bounds how many submissions are graded at once. Submissions
+// beyond the number of workers wait their turn in a FIFO queue of limited
+// length, and each user may only have a few submissions running or waiting.
+type gradingQueue struct {
+	workers    int
+	maxQueued  int
+	maxPerUser int // 0 for no limit
+
+	mu      sync.Mutex
+	running int
+	waiting []*queueTicket
+	perUser map[string]int // submissions running or waiting, by user
+}
+
+// queueTicket is a submission waiting for a worker
+type queueTicket struct {
+	user  string
+	ready chan struct{} // closed when the submission may start
+	moved chan struct{} // signalled when the queue ahead of it shrinks
+}
+
+func newGradingQueue(workers, maxQueued, maxPerUser int) *gradingQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	return &gradingQueue{
+		workers:    workers,
+		maxQueued:  maxQueued,
+		maxPerUser: maxPerUser,
+		perUser:    make(map[string]int),
+	}
+}
+
+// acquire waits for a worker to grade a submission by user, as returned by
+// submitterKey, calling onPosition with the submission's 1-based place in
+// the queue whenever it changes. It fails with RESOURCE_EXHAUSTED when the
+// queue is full or the user is at their limit, and with the context's
+// error when ctx ends first. The returned function frees the worker.
+func (q *gradingQueue) acquire(ctx context.Context, user string, onPosition func(int) error) (func(), error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	q.mu.Lock()
+	if q.maxPerUser > 0 && q.perUser[user] >= q.maxPerUser {
+		q.mu.Unlock()
+		return nil, resourceExhausted(user,
+			fmt.Sprintf("you already have %d submissions being graded; wait for them to finish", q.maxPerUser),
+			queueRetryDelay)
+	}
+	if q.running < q.workers && len(q.waiting) == 0 {
+		q.running++
+		q.perUser[user]++
+		q.mu.Unlock()
+		return func() { q.release(user) }, nil
+	}
+	if len(q.waiting) >= q.maxQueued {
+		q.mu.Unlock()
+		return nil, resourceExhausted("grading-queue",
+			fmt.Sprintf("the grading queue is full (%d waiting); try again shortly", len(q.waiting)),
+			queueRetryDelay)
+	}
+
+	ticket := &queueTicket{
+		user:  user,
+		ready: make(chan struct{}),
+		moved: make(chan struct{}, 1),
+	}
+	q.waiting = append(q.waiting, ticket)
+	q.perUser[user]++
+	q.mu.Unlock()
+
+	reported := 0
+	for {
+		if position := q.position(ticket); position > 0 && position != reported {
+			reported = position
+			if onPosition != nil {
+				if err := onPosition(position); err != nil {
+					q.abandon(ticket)
+					return nil, err
+				}
+			}
+		}
+
+		select {
+		case <-ticket.ready:
+			return func() { q.release(user) }, nil
+		case <-ticket.moved:
+		case <-ctx.Done():
+			q.abandon(ticket)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// submitterKey identifies who a submission counts against for the per-user
+// limit: the user, or the peer address of anonymous submissions, so that
+// leaving out the user ID does not get around the limit. Submissions that
+// come through the REST gateway all share its address.
+func submitterKey(ctx context.Context, user string) string {
+	if user != "" {
+		return "user:" + user
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "anonymous"
+	}
+	host := p.Addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return "peer:" + host
+}
+
+// position returns where ticket is in the queue, or 0 once it has left
+func (q *gradingQueue) position(ticket *queueTicket) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, t := range q.waiting {
+		if t == ticket {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// abandon drops a ticket whose client stopped waiting. If a worker was
+// handed to it in the meantime, the worker is passed on.
+func (q *gradingQueue) abandon(ticket *queueTicket) {
+	q.mu.Lock()
+	for i, t := range q.waiting {
+		if t == ticket {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			q.removeUser(ticket.user)
+			q.notifyMoved()
+			q.mu.Unlock()
+			return
+		}
+	}
+	q.mu.Unlock()
+	q.release(ticket.user)
+}
+
+// release frees a worker and hands it to the first waiting submission
+func (q *gradingQueue) release(user string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.running--
+	q.removeUser(user)
+
+	if len(q.waiting) == 0 {
+		return
+	}
+	next := q.waiting[0]
+	q.waiting = q.waiting[1:]
+	q.running++
+	close(next.ready)
+	q.notifyMoved()
+}
+
+func (q *gradingQueue) removeUser(user string) {
+	q.perUser[user]--
+	if q.perUser[user] <= 0 {
+		delete(q.perUser, user)
+	}
+}
+
+// notifyMoved wakes every waiting submission to report its new position
+func (q *gradingQueue) notifyMoved() {
+	for _, t := range q.waiting {
+		select {
+		case t.moved <- struct{}{}:
+		default:
+		}
+	}
+}
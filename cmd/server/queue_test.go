@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waiter is a submission blocked in acquire, with the positions it reports
+type waiter struct {
+	positions chan int
+	done      chan error
+	release   func()
+	cancel    context.CancelFunc
+}
+
+// startWaiter calls acquire in the background and waits until the
+// submission reports its first position in the queue
+func startWaiter(t *testing.T, q *gradingQueue, user string) *waiter {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &waiter{positions: make(chan int, 10), done: make(chan error, 1), cancel: cancel}
+	go func() {
+		release, err := q.acquire(ctx, user, func(position int) error {
+			w.positions <- position
+			return nil
+		})
+		w.release = release
+		w.done <- err
+	}()
+	t.Cleanup(cancel)
+	w.expectPosition(t, 0)
+	return w
+}
+
+// expectPosition waits for the next position reported, failing unless it
+// is want; 0 accepts any position
+func (w *waiter) expectPosition(t *testing.T, want int) {
+	t.Helper()
+	select {
+	case got := <-w.positions:
+		if want != 0 && got != want {
+			t.Fatalf("position = %d, want %d", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no position reported")
+	}
+}
+
+// wait waits for acquire to return
+func (w *waiter) wait(t *testing.T) error {
+	t.Helper()
+	select {
+	case err := <-w.done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("acquire did not return")
+		return nil
+	}
+}
+
+// checkIdle fails unless no worker is taken and nobody is waiting
+func checkIdle(t *testing.T, q *gradingQueue) {
+	t.Helper()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.running != 0 || len(q.waiting) != 0 || len(q.perUser) != 0 {
+		t.Errorf("queue not idle: %d running, %d waiting, users %v", q.running, len(q.waiting), q.perUser)
+	}
+}
+
+func TestGradingQueuePerUserLimit(t *testing.T) {
+	q := newGradingQueue(1, 10, 2)
+	ctx := context.Background()
+
+	release, err := q.acquire(ctx, "alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued := startWaiter(t, q, "alice")
+
+	if _, err := q.acquire(ctx, "alice", nil); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third submission by the same user: error %v, want RESOURCE_EXHAUSTED", err)
+	}
+	other := startWaiter(t, q, "bob")
+
+	release()
+	if err := queued.wait(t); err != nil {
+		t.Fatal(err)
+	}
+	queued.release()
+	if err := other.wait(t); err != nil {
+		t.Fatal(err)
+	}
+	other.release()
+	checkIdle(t, q)
+}
+
+func TestGradingQueueFull(t *testing.T) {
+	q := newGradingQueue(1, 1, 0)
+	ctx := context.Background()
+
+	release, err := q.acquire(ctx, "alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queued := startWaiter(t, q, "bob")
+	if _, err := q.acquire(ctx, "carol", nil); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("submission to a full queue: error %v, want RESOURCE_EXHAUSTED", err)
+	}
+
+	release()
+	if err := queued.wait(t); err != nil {
+		t.Fatal(err)
+	}
+	queued.release()
+	checkIdle(t, q)
+}
+
+func TestGradingQueuePositions(t *testing.T) {
+	q := newGradingQueue(1, 10, 0)
+	release, err := q.acquire(context.Background(), "alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := startWaiter(t, q, "bob")
+	second := startWaiter(t, q, "carol")
+	third := startWaiter(t, q, "dave")
+
+	// A client that gives up makes room for those behind it.
+	second.cancel()
+	if err := second.wait(t); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled submission: error %v, want %v", err, context.Canceled)
+	}
+	third.expectPosition(t, 2)
+
+	release()
+	if err := first.wait(t); err != nil {
+		t.Fatal(err)
+	}
+	third.expectPosition(t, 1)
+
+	first.release()
+	if err := third.wait(t); err != nil {
+		t.Fatal(err)
+	}
+	third.release()
+	checkIdle(t, q)
+}
+
+func TestGradingQueueAbandonAfterHandoff(t *testing.T) {
+	q := newGradingQueue(1, 10, 0)
+	release, err := q.acquire(context.Background(), "alice", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client goes away while the worker is being handed to it: its
+	// position update fails after the ticket has already left the queue.
+	reporting := make(chan struct{})
+	failReport := make(chan struct{})
+	gone := errors.New("client gone")
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.acquire(context.Background(), "bob", func(int) error {
+			close(reporting)
+			<-failReport
+			return gone
+		})
+		done <- err
+	}()
+	<-reporting
+	release()
+	close(failReport)
+	if err := <-done; !errors.Is(err, gone) {
+		t.Fatalf("abandoned submission: error %v, want %v", err, gone)
+	}
+
+	// The worker it was handed must have been passed on.
+	checkIdle(t, q)
+	release, err = q.acquire(context.Background(), "carol", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// watchLessons polls the lesson directories and packs every interval and
+// reloads the lessons whenever a file in them changes. Polling catches
+// edits made by any editor, on any file system, without watching each
+// directory separately.
+func (s *server) watchLessons(interval time.Duration) {
+	stamp, err := sourcesStamp(s.sources)
+	if err != nil {
+		log.Printf("Failed to watch lessons: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		next, err := sourcesStamp(s.sources)
+		if err != nil {
+			log.Printf("Failed to watch lessons: %v", err)
+			continue
+		}
+		if next == stamp {
+			continue
+		}
+		stamp = next
+		s.reloadLessons()
+	}
+}
+
+// reloadLessons reads the lessons again and swaps them in as a whole. If
+// any lesson is invalid, the lessons being served are kept, so that a
+// half-finished edit never takes a lesson away from students.
+func (s *server) reloadLessons() {
+	lessons, err := s.readLessons()
+	if err != nil {
+		log.Printf("Not reloading lessons, keeping the current ones: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	old := s.lessons
+	s.lessons = lessons
+	s.mu.Unlock()
+
+	added, removed, changed := diffLessons(old, lessons)
+	if len(added)+len(removed)+len(changed) == 0 {
+		return
+	}
+	log.Printf("Reloaded lessons: %s", describeChanges(added, removed, changed))
+	for _, problem := range curriculumProblems(lessons) {
+		log.Printf("Warning: %s; run with -check-lessons for details", problem)
+	}
+}
+
+// sourcesStamp summarises the name, size and modification time of every
+// file the sources are read from, so that any change to them changes the
+// stamp. The embedded lessons never change and are left out.
+func sourcesStamp(sources []lessonSource) (string, error) {
+	h := sha256.New()
+	for _, src := range sources {
+		if src.file == "" {
+			continue
+		}
+		err := filepath.WalkDir(src.file, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00%v\n", path, info.Size(), info.ModTime().UnixNano(), info.Mode())
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// diffLessons compares two lesson sets by ID
+func diffLessons(old, lessons map[int32]*Lesson) (added, removed, changed []int32) {
+	for id, lesson := range lessons {
+		prev, ok := old[id]
+		switch {
+		case !ok:
+			added = append(added, id)
+		case prev.fingerprint() != lesson.fingerprint():
+			changed = append(changed, id)
+		}
+	}
+	for id := range old {
+		if _, ok := lessons[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	for _, ids := range [][]int32{added, removed, changed} {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+	return added, removed, changed
+}
+
+// fingerprint identifies everything a lesson serves and grades with
+func (l *Lesson) fingerprint() string {
+	data, err := json.Marshal(struct {
+		*Lesson
+		ProvidedFiles map[string]string
+		Harnesses     map[string]string
+		Dir           string
+	}{l, l.ProvidedFiles, l.Harnesses, l.Dir})
+	if err != nil {
+		// Every field marshals; treat the impossible as a change
+		return fmt.Sprintf("%p", l)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// describeChanges renders the outcome of diffLessons for the log, e.g.
+// "added 4; changed 1, 2"
+func describeChanges(added, removed, changed []int32) string {
+	var parts []string
+	for _, group := range []struct {
+		verb string
+		ids  []int32
+	}{{"added", added}, {"removed", removed}, {"changed", changed}} {
+		if len(group.ids) == 0 {
+			continue
+		}
+		ids := make([]string, len(group.ids))
+		for i, id := range group.ids {
+			ids[i] = fmt.Sprint(id)
+		}
+		parts = append(parts, group.verb+" "+strings.Join(ids, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
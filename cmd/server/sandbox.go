@@ -0,0 +1,558 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// sandboxUIDBase and sandboxUIDCount give the uids sandboxed processes run
+// as when the server is root, one per concurrent run: RLIMIT_NPROC then
+// bounds every run on its own, and runs cannot touch each other's files.
+// systemd leaves this range unallocated.
+const (
+	sandboxUIDBase  = 65536
+	sandboxUIDCount = 1024
+)
+
+// sandboxUIDs holds the sandbox uids not taken by a run
+var sandboxUIDs = newUIDPool(sandboxUIDBase, sandboxUIDCount)
+
+// processLimitWarning is logged when nothing bounds the number of processes
+// sandboxed programs start
+const processLimitWarning = "WARNING: nothing limits how many processes sandboxed programs start, so a single fork bomb can exhaust the host; run the server as root, or use the namespace sandbox with a delegated cgroup v2 hierarchy (-cgroup-root)"
+
+// newSandbox creates the sandbox backend selected by kind: "namespace",
+// "rlimit" or "auto", which prefers namespaces and falls back to rlimits.
+func newSandbox(kind, cgroupRoot string) (Sandbox, error) {
+	switch kind {
+	case "rlimit":
+		return newRlimitSandbox(), nil
+	case "namespace":
+		return newNamespaceSandbox(cgroupRoot)
+	case "auto":
+		sb, err := newNamespaceSandbox(cgroupRoot)
+		if err == nil {
+			return sb, nil
+		}
+		log.Printf("Namespace sandbox unavailable, falling back to rlimits: %v", err)
+		return newRlimitSandbox(), nil
+	default:
+		return nil, fmt.Errorf("unknown sandbox %q (want auto, namespace or rlimit)", kind)
+	}
+}
+
+// rlimitSandbox only applies per-process rlimits and a wall-clock timeout.
+// It is the portable fallback when namespaces are not available, and leaves
+// the whole filesystem readable to the program.
+type rlimitSandbox struct{}
+
+func newRlimitSandbox() *rlimitSandbox {
+	if runsAsServerUser() {
+		log.Print(processLimitWarning)
+	}
+	return &rlimitSandbox{}
+}
+
+func (s *rlimitSandbox) Name() string { return "rlimit" }
+
+func (s *rlimitSandbox) Run(ctx context.Context, spec RunSpec) (*RunResult, error) {
+	attr := &syscall.SysProcAttr{Setpgid: true}
+	uid, err := dropPrivileges(attr, spec.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if uid != 0 {
+		defer sandboxUIDs.release(uid)
+	}
+	return runHelper(ctx, spec, attr, helperConfig{Rlimits: rlimitsFor(spec.Limits, uid != 0, !spec.NoAddressSpaceLimit)})
+}
+
+// ExposesServerFiles is true unless the server is root: programs then run
+// as the server's user with the whole filesystem in reach.
+func (s *rlimitSandbox) ExposesServerFiles() bool {
+	return runsAsServerUser()
+}
+
+func (s *rlimitSandbox) Close() error { return nil }
+
+// runsAsServerUser reports whether sandboxed processes share the server's
+// uid, which is the case unless the server is root and can switch to a
+// sandbox uid.
+func runsAsServerUser() bool {
+	return os.Geteuid() != 0
+}
+
+// dropPrivileges makes the process run as a sandbox uid of its own when the
+// server is root, so that RLIMIT_NPROC bounds it alone, and hands dir and
+// its contents over to that user. It returns the uid, which the caller
+// releases after the run, or 0 when the process keeps the server's.
+func dropPrivileges(attr *syscall.SysProcAttr, dir string) (int, error) {
+	if runsAsServerUser() {
+		return 0, nil
+	}
+	uid, err := acquireSandboxUID(dir)
+	if err != nil {
+		return 0, err
+	}
+	attr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(uid)}
+	return uid, nil
+}
+
+// acquireSandboxUID takes a free sandbox uid and hands dir and its contents
+// over to it
+func acquireSandboxUID(dir string) (int, error) {
+	uid, err := sandboxUIDs.acquire()
+	if err != nil {
+		return 0, err
+	}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, uid)
+	})
+	if err != nil {
+		sandboxUIDs.release(uid)
+		return 0, fmt.Errorf("failed to hand work directory to sandbox user: %v", err)
+	}
+	return uid, nil
+}
+
+// uidPool hands out uids from a fixed range
+type uidPool struct {
+	mu   sync.Mutex
+	free []int
+}
+
+func newUIDPool(base, count int) *uidPool {
+	p := &uidPool{}
+	for uid := base + count - 1; uid >= base; uid-- {
+		p.free = append(p.free, uid)
+	}
+	return p
+}
+
+func (p *uidPool) acquire() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.free) == 0 {
+		return 0, errors.New("every sandbox uid is in use")
+	}
+	uid := p.free[len(p.free)-1]
+	p.free = p.free[:len(p.free)-1]
+	return uid, nil
+}
+
+func (p *uidPool) release(uid int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.free = append(p.free, uid)
+}
+
+// helperConfig is what the parent passes to the sandbox helper.
+type helperConfig struct {
+	Rlimits rlimitConfig `json:"rlimits"`
+
+	// Isolation, when set, makes the helper move into a minimal filesystem
+	// and drop its privileges before exec.
+	Isolation *isolationConfig `json:"isolation,omitempty"`
+
+	// StatusFD is where a helper that stays behind as init reports the
+	// program's wait status.
+	StatusFD int `json:"status_fd"`
+}
+
+// isolationConfig describes the filesystem a namespaced helper builds for
+// the program. Only the namespace sandbox sets it.
+type isolationConfig struct {
+	Root  string   `json:"root"`  // empty host directory to mount the new root on
+	Dir   string   `json:"dir"`   // working directory of the program
+	Binds []string `json:"binds"` // host directories mounted read-write at the same path
+	User  int      `json:"user"`  // uid and gid to switch to, or 0 to only drop capabilities
+}
+
+// rlimitConfig is what the parent passes to the sandbox helper. Zero values
+// leave the corresponding limit untouched.
+type rlimitConfig struct {
+	CPUSeconds   uint64 `json:"cpu"`
+	AddressSpace uint64 `json:"as"`
+	Processes    uint64 `json:"nproc"`
+	FileSize     uint64 `json:"fsize"`
+	OpenFiles    uint64 `json:"nofile"`
+}
+
+// rlimitsFor translates l into helper rlimits. RLIMIT_NPROC counts every
+// process of the real uid, so it is only useful when running as a dedicated
+// user; limitMemory is false when a cgroup enforces memory instead.
+func rlimitsFor(l Limits, dedicatedUser, limitMemory bool) rlimitConfig {
+	cfg := rlimitConfig{
+		CPUSeconds: uint64((l.CPUTimeMs + 999) / 1000),
+		FileSize:   uint64(l.FileSizeKB) * 1024,
+		OpenFiles:  64,
+	}
+	if limitMemory {
+		cfg.AddressSpace = uint64(l.MemoryMB) * 1024 * 1024
+	}
+	if dedicatedUser {
+		cfg.Processes = uint64(l.MaxProcesses)
+	}
+	return cfg
+}
+
+// runHelper re-executes the server binary as the sandbox helper with attr and
+// helper applied, enforces the wall-clock and output limits, and classifies
+// how the process finished.
+func runHelper(ctx context.Context, spec RunSpec, attr *syscall.SysProcAttr, helper helperConfig) (*RunResult, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate sandbox helper: %v", err)
+	}
+	// The status pipe follows the program's own extra files.
+	helper.StatusFD = 3 + len(spec.ExtraFiles)
+	cfg, err := json.Marshal(helper)
+	if err != nil {
+		return nil, err
+	}
+	statusR, statusW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer statusR.Close()
+
+	runCtx, cancel := context.WithTimeout(ctx, time.Duration(spec.Limits.WallTimeMs)*time.Millisecond)
+	defer cancel()
+
+	args := []string{sandboxHelperArg, string(cfg), "--"}
+	if spec.Path != "" {
+		args = append(append(args, spec.Path), spec.Args...)
+	}
+	cmd := exec.Command(self, args...)
+	cmd.Dir = spec.Dir
+	cmd.Env = spec.Env
+	if cmd.Env == nil {
+		cmd.Env = defaultEnv(spec.Dir)
+	}
+	cmd.Stdin = strings.NewReader(spec.Stdin)
+	cmd.SysProcAttr = attr
+	cmd.WaitDelay = time.Second
+	cmd.ExtraFiles = append(append([]*os.File(nil), spec.ExtraFiles...), statusW)
+
+	output := newLimitedBuffer(spec.Limits.OutputKB*1024, cancel)
+	cmd.Stdout = output
+	var stderr *limitedBuffer
+	if spec.MergeStderr {
+		cmd.Stderr = output
+	} else {
+		stderr = newLimitedBuffer(spec.Limits.OutputKB*1024, cancel)
+		cmd.Stderr = stderr
+	}
+
+	start := time.Now()
+	err = cmd.Start()
+	statusW.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sandboxed process: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var waitErr error
+	killed := false
+	select {
+	case waitErr = <-done:
+	case <-runCtx.Done():
+		killed = true
+		// Kill the whole process group so forked children die too.
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		waitErr = <-done
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) && !errors.Is(waitErr, exec.ErrWaitDelay) {
+		return nil, fmt.Errorf("failed to wait for sandboxed process: %v", waitErr)
+	}
+
+	result := &RunResult{
+		Stdout:   output.Bytes(),
+		Duration: time.Since(start),
+	}
+	if stderr != nil {
+		result.Stderr = stderr.Bytes()
+	}
+
+	state := cmd.ProcessState
+	status, _ := state.Sys().(syscall.WaitStatus)
+	// Only the helper holds the status pipe, so it is closed by now.
+	if report, _ := io.ReadAll(io.LimitReader(statusR, 32)); len(report) > 0 {
+		if n, err := strconv.ParseUint(strings.TrimSpace(string(report)), 10, 32); err == nil {
+			status = syscall.WaitStatus(n)
+		}
+	}
+	result.ExitCode = status.ExitStatus()
+
+	switch {
+	case output.Overflowed() || (stderr != nil && stderr.Overflowed()):
+		result.Status = StatusOutputLimit
+	case killed:
+		result.Status = StatusTimeLimit
+		result.Signal = syscall.SIGKILL
+	case status.Signaled():
+		result.Signal = status.Signal()
+		result.Status = classifySignal(result.Signal, state.UserTime()+state.SystemTime(), spec.Limits)
+	case result.ExitCode != 0:
+		result.Status = StatusRuntimeError
+	default:
+		result.Status = StatusOK
+	}
+
+	// Under RLIMIT_AS an exhausted heap shows up as a failed malloc and
+	// usually a crash, so treat a failure close to the limit as memory.
+	if result.Status == StatusSignaled || result.Status == StatusRuntimeError {
+		if ru, ok := state.SysUsage().(*syscall.Rusage); ok && helper.Rlimits.AddressSpace > 0 &&
+			uint64(maxRSSBytes(ru)) >= helper.Rlimits.AddressSpace/10*9 {
+			result.Status = StatusMemoryLimit
+		}
+	}
+	return result, nil
+}
+
+// classifySignal maps a terminating signal to a limit where the kernel uses
+// a signal to enforce it.
+func classifySignal(sig syscall.Signal, cpu time.Duration, l Limits) ExecStatus {
+	switch sig {
+	case syscall.SIGXCPU:
+		return StatusTimeLimit
+	case syscall.SIGXFSZ:
+		return StatusOutputLimit
+	case syscall.SIGKILL:
+		// The hard CPU limit is enforced with SIGKILL one second after SIGXCPU.
+		if cpu >= time.Duration(l.CPUTimeMs)*time.Millisecond {
+			return StatusTimeLimit
+		}
+	}
+	return StatusSignaled
+}
+
+// limitedBuffer keeps at most max bytes and calls onOverflow once when more
+// are written, so runaway output stops the process instead of filling memory.
+type limitedBuffer struct {
+	mu         sync.Mutex
+	buf        bytes.Buffer
+	max        int64
+	overflowed bool
+	onOverflow func()
+}
+
+func newLimitedBuffer(max int64, onOverflow func()) *limitedBuffer {
+	return &limitedBuffer{max: max, onOverflow: onOverflow}
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if room := b.max - int64(b.buf.Len()); int64(len(p)) > room {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		if !b.overflowed {
+			b.overflowed = true
+			b.onOverflow()
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
+func (b *limitedBuffer) Overflowed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.overflowed
+}
+
+// runSandboxHelper is the entry point of the helper process. args are the
+// helper configuration, "--", and the program to exec with its arguments.
+// Without a program it exits successfully, which is used to probe backends.
+func runSandboxHelper(args []string) {
+	if len(args) < 2 || args[1] != "--" {
+		fmt.Fprintln(os.Stderr, "sandbox: malformed helper invocation")
+		os.Exit(126)
+	}
+
+	// Capabilities are per thread, so drop them on the thread that execs.
+	runtime.LockOSThread()
+
+	var cfg helperConfig
+	if err := json.Unmarshal([]byte(args[0]), &cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: invalid configuration: %v\n", err)
+		os.Exit(126)
+	}
+	// The program must never get hold of the status pipe.
+	if cfg.StatusFD > 0 {
+		syscall.CloseOnExec(cfg.StatusFD)
+	}
+	if cfg.Isolation != nil {
+		if err := cfg.Isolation.enter(); err != nil {
+			fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+			os.Exit(126)
+		}
+	}
+	if err := applyRlimits(cfg.Rlimits); err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+		os.Exit(126)
+	}
+	if cfg.Isolation != nil {
+		if err := cfg.Isolation.dropPrivileges(); err != nil {
+			fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+			os.Exit(126)
+		}
+	}
+
+	argv := args[2:]
+	if len(argv) == 0 {
+		os.Exit(0)
+	}
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %v\n", err)
+		os.Exit(127)
+	}
+	if os.Getpid() == 1 {
+		runInit(path, argv, cfg.StatusFD)
+	}
+	err = syscall.Exec(path, argv, os.Environ())
+	fmt.Fprintf(os.Stderr, "sandbox: failed to exec %s: %v\n", path, err)
+	os.Exit(126)
+}
+
+// runInit runs the program as a child of the helper, which stays behind as
+// init of the PID namespace: the kernel drops signals a namespace's init
+// sends itself, so a program running as init could not abort or kill itself.
+// The helper reaps orphans until the program exits, and then reports its
+// wait status on statusFD for the parent to use instead of its own. The
+// program inherits every fd below statusFD.
+func runInit(path string, argv []string, statusFD int) {
+	var files []uintptr
+	for fd := 0; fd < statusFD; fd++ {
+		files = append(files, uintptr(fd))
+	}
+	pid, err := syscall.ForkExec(path, argv, &syscall.ProcAttr{Env: os.Environ(), Files: files})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: failed to exec %s: %v\n", path, err)
+		os.Exit(126)
+	}
+	for {
+		var status syscall.WaitStatus
+		reaped, err := syscall.Wait4(-1, &status, 0, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "sandbox: failed to wait for %s: %v\n", path, err)
+			os.Exit(126)
+		}
+		if reaped == pid {
+			fmt.Fprintf(os.NewFile(uintptr(statusFD), "status"), "%d\n", uint32(status))
+			os.Exit(0)
+		}
+	}
+}
+
+// applyRlimits sets every non-zero limit in cfg on the current process
+func applyRlimits(cfg rlimitConfig) error {
+	limits := []struct {
+		resource int
+		soft     uint64
+		hard     uint64
+	}{
+		// The soft CPU limit raises SIGXCPU; the hard one a second later kills.
+		{unix.RLIMIT_CPU, cfg.CPUSeconds, cfg.CPUSeconds + 1},
+		{rlimitAddressSpace, cfg.AddressSpace, cfg.AddressSpace},
+		{rlimitProcesses, cfg.Processes, cfg.Processes},
+		{unix.RLIMIT_FSIZE, cfg.FileSize, cfg.FileSize},
+		{unix.RLIMIT_NOFILE, cfg.OpenFiles, cfg.OpenFiles},
+	}
+	for _, l := range limits {
+		if l.soft == 0 || l.resource < 0 {
+			continue
+		}
+		var rl unix.Rlimit
+		setLimit(&rl.Cur, l.soft)
+		setLimit(&rl.Max, l.hard)
+		if err := unix.Setrlimit(l.resource, &rl); err != nil {
+			return fmt.Errorf("failed to set rlimit %d: %v", l.resource, err)
+		}
+	}
+	// Core dumps of student programs are never useful and can be large.
+	return unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{})
+}
+
+// setLimit stores v in an rlimit field, which is signed on some BSDs
+func setLimit[T int64 | uint64](field *T, v uint64) {
+	*field = T(v)
+}
+
+// readRunFile reads the file name in dir, which a sandboxed program may have
+// created or replaced. Symlinks, FIFOs and devices are refused rather than
+// followed or blocked on, and files larger than max bytes are rejected.
+func readRunFile(dir, name string, max int64) ([]byte, error) {
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_RDONLY|syscall.O_NOFOLLOW|syscall.O_NONBLOCK, 0)
+	if errors.Is(err, syscall.ELOOP) {
+		return nil, errNotRegularFile
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errNotRegularFile
+	}
+	data, err := io.ReadAll(io.LimitReader(f, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, errFileTooLarge
+	}
+	return data, nil
+}
+
+// signalName returns the conventional name of sig, such as "SIGSEGV"
+func signalName(sig syscall.Signal) string {
+	return unix.SignalName(sig)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// namespaceSandbox runs each process in fresh PID, mount, network, IPC and
+// UTS namespaces, and inside a cgroup v2 leaf when cgroups are available.
+// The helper stays behind as init of the PID namespace, so every process
+// the program forks is killed as soon as the program exits. The mount
+// namespace gets a minimal read-only root holding only the system
+// directories and the run's own, so lessons, other runs and the server's
+// data stay out of reach.
+type namespaceSandbox struct {
+	cgroups *cgroupManager // nil when cgroup v2 is not usable
+	root    string         // empty directory each run mounts its root on
+}
+
+func newNamespaceSandbox(cgroupRoot string) (Sandbox, error) {
+	root, err := os.MkdirTemp("", "c-learning-root-*")
+	if err != nil {
+		return nil, err
+	}
+	s := &namespaceSandbox{root: root}
+	if cgroupRoot != "" {
+		cg, err := newCgroupManager(cgroupRoot)
+		if err != nil {
+			log.Printf("Cgroup v2 unavailable, relying on rlimits for memory and processes: %v", err)
+		} else {
+			s.cgroups = cg
+		}
+	}
+
+	// Probe with a no-op helper run: namespaces are often disabled in
+	// containers or for unprivileged users.
+	dir, err := os.MkdirTemp("", "c-learning-probe-*")
+	if err != nil {
+		os.Remove(root)
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	res, err := s.Run(context.Background(), RunSpec{Dir: dir, Limits: defaultRunLimits})
+	if err == nil && res.Status != StatusOK {
+		err = fmt.Errorf("probe process failed: %s: %s", res.Status, res.Stderr)
+	}
+	if err != nil {
+		os.Remove(root)
+		return nil, err
+	}
+	if s.cgroups == nil && runsAsServerUser() {
+		log.Print(processLimitWarning)
+	}
+	return s, nil
+}
+
+func (s *namespaceSandbox) Name() string {
+	if s.cgroups != nil {
+		return "namespace+cgroup"
+	}
+	return "namespace"
+}
+
+// ExposesServerFiles is false: programs only see their own directories.
+func (s *namespaceSandbox) ExposesServerFiles() bool { return false }
+
+// Close removes the directory runs mount their root on
+func (s *namespaceSandbox) Close() error {
+	return os.Remove(s.root)
+}
+
+func (s *namespaceSandbox) Run(ctx context.Context, spec RunSpec) (*RunResult, error) {
+	attr := &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+		Cloneflags: syscall.CLONE_NEWPID | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS,
+	}
+
+	// The helper keeps its privileges until it has built the filesystem, and
+	// switches to a sandbox uid or gives up its capabilities just before exec.
+	isolation := &isolationConfig{Root: s.root, Dir: spec.Dir, Binds: spec.Binds}
+	dedicated := !runsAsServerUser()
+	if dedicated {
+		uid, err := acquireSandboxUID(spec.Dir)
+		if err != nil {
+			return nil, err
+		}
+		defer sandboxUIDs.release(uid)
+		isolation.User = uid
+	} else {
+		// Unprivileged servers need a user namespace to create the others,
+		// and to be root in it to mount.
+		attr.Cloneflags |= syscall.CLONE_NEWUSER
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	}
+
+	if s.cgroups == nil {
+		return runHelper(ctx, spec, attr, helperConfig{
+			Rlimits:   rlimitsFor(spec.Limits, dedicated, !spec.NoAddressSpaceLimit),
+			Isolation: isolation,
+		})
+	}
+
+	cg, err := s.cgroups.create(spec.Limits)
+	if err != nil {
+		return nil, err
+	}
+	defer cg.destroy()
+
+	fd, err := syscall.Open(cg.path, syscall.O_RDONLY|syscall.O_DIRECTORY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cgroup: %v", err)
+	}
+	defer syscall.Close(fd)
+	attr.UseCgroupFD = true
+	attr.CgroupFD = fd
+
+	// The cgroup enforces memory, so skip RLIMIT_AS to get a clean OOM kill
+	// that can be reported as such instead of a failed malloc.
+	res, err := runHelper(ctx, spec, attr, helperConfig{
+		Rlimits:   rlimitsFor(spec.Limits, dedicated, false),
+		Isolation: isolation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cg.oomKilled() {
+		res.Status = StatusMemoryLimit
+	}
+	return res, nil
+}
+
+// cgroupManager creates one cgroup v2 leaf per sandboxed run under root.
+type cgroupManager struct {
+	root string
+}
+
+func newCgroupManager(root string) (*cgroupManager, error) {
+	parent := filepath.Dir(root)
+	if _, err := os.Stat(filepath.Join(parent, "cgroup.controllers")); err != nil {
+		return nil, fmt.Errorf("%s is not a cgroup v2 hierarchy", parent)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cgroup %s: %v", root, err)
+	}
+
+	// Controllers must be enabled on every level above the leaves.
+	for _, dir := range []string{parent, root} {
+		path := filepath.Join(dir, "cgroup.subtree_control")
+		if err := os.WriteFile(path, []byte("+memory +pids"), 0644); err != nil {
+			return nil, fmt.Errorf("failed to enable memory and pids controllers in %s: %v", dir, err)
+		}
+	}
+	return &cgroupManager{root: root}, nil
+}
+
+// create makes a new leaf cgroup limited according to l
+func (m *cgroupManager) create(l Limits) (*cgroup, error) {
+	path, err := os.MkdirTemp(m.root, "run-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cgroup: %v", err)
+	}
+	cg := &cgroup{path: path}
+
+	settings := map[string]string{
+		"memory.max":      strconv.FormatInt(l.MemoryMB*1024*1024, 10),
+		"memory.swap.max": "0",
+		"pids.max":        strconv.FormatInt(l.MaxProcesses, 10),
+	}
+	for file, value := range settings {
+		if err := os.WriteFile(filepath.Join(path, file), []byte(value), 0644); err != nil && !os.IsNotExist(err) {
+			cg.destroy()
+			return nil, fmt.Errorf("failed to set %s: %v", file, err)
+		}
+	}
+	return cg, nil
+}
+
+type cgroup struct {
+	path string
+}
+
+// oomKilled reports whether the kernel OOM killer fired inside the cgroup
+func (c *cgroup) oomKilled() bool {
+	f, err := os.Open(filepath.Join(c.path, "memory.events"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "oom_kill" {
+			n, _ := strconv.Atoi(fields[1])
+			return n > 0
+		}
+	}
+	return false
+}
+
+// destroy kills anything left in the cgroup and removes it
+func (c *cgroup) destroy() {
+	os.WriteFile(filepath.Join(c.path, "cgroup.kill"), []byte("1"), 0644)
+
+	// Killed processes leave the cgroup asynchronously.
+	var err error
+	for i := 0; i < 50; i++ {
+		if err = os.Remove(c.path); err == nil || os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	log.Printf("Failed to remove cgroup %s: %v", c.path, err)
+}
+
+// maxRSSBytes returns the peak resident set size; Linux reports it in KiB.
+func maxRSSBytes(ru *syscall.Rusage) int64 {
+	return ru.Maxrss * 1024
+}
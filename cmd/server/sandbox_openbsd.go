@@ -0,0 +1,10 @@
+package main
+
+import "golang.org/x/sys/unix"
+
+// OpenBSD has no address space limit, but the data segment limit bounds
+// malloc.
+const (
+	rlimitAddressSpace = unix.RLIMIT_DATA
+	rlimitProcesses    = unix.RLIMIT_NPROC
+)
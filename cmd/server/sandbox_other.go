@@ -0,0 +1,29 @@
+//go:build !unix
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// newSandbox fails: every backend relies on Unix process control.
+func newSandbox(kind, cgroupRoot string) (Sandbox, error) {
+	return nil, errors.New("sandboxed runs are only supported on Unix systems")
+}
+
+func runSandboxHelper(args []string) {
+	fmt.Fprintln(os.Stderr, "sandbox: not supported on this platform")
+	os.Exit(126)
+}
+
+func readRunFile(dir, name string, max int64) ([]byte, error) {
+	return nil, errors.New("sandboxed runs are only supported on Unix systems")
+}
+
+// signalName returns the conventional name of sig
+func signalName(sig syscall.Signal) string {
+	return sig.String()
+}
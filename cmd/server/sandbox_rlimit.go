@@ -0,0 +1,11 @@
+//go:build unix && !openbsd && !solaris
+
+package main
+
+import "golang.org/x/sys/unix"
+
+// Rlimits that vary between systems. A negative one is not supported.
+const (
+	rlimitAddressSpace = unix.RLIMIT_AS
+	rlimitProcesses    = unix.RLIMIT_NPROC
+)
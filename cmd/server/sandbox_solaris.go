@@ -0,0 +1,10 @@
+package main
+
+import "golang.org/x/sys/unix"
+
+// Solaris has no rlimit on the number of processes, so the rlimit sandbox
+// cannot bound them there.
+const (
+	rlimitAddressSpace = unix.RLIMIT_AS
+	rlimitProcesses    = -1
+)
@@ -0,0 +1,26 @@
+//go:build unix && !linux
+
+package main
+
+import (
+	"errors"
+	"syscall"
+)
+
+func newNamespaceSandbox(cgroupRoot string) (Sandbox, error) {
+	return nil, errors.New("namespace sandbox is only supported on Linux")
+}
+
+// enter is never reached: only the namespace sandbox isolates the filesystem.
+func (c *isolationConfig) enter() error {
+	return errors.New("filesystem isolation is only supported on Linux")
+}
+
+func (c *isolationConfig) dropPrivileges() error {
+	return errors.New("filesystem isolation is only supported on Linux")
+}
+
+// maxRSSBytes returns the peak resident set size, which BSDs report in bytes.
+func maxRSSBytes(ru *syscall.Rusage) int64 {
+	return int64(ru.Maxrss)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+// sandboxHelperArg is the hidden first argument that makes the server binary
+// act as the sandbox helper: it applies rlimits to itself and then execs the
+// target program, so limits are in place before any student code runs.
+const sandboxHelperArg = "__sandbox-exec"
+
+// Limits bounds the resources a sandboxed process may consume. Zero values
+// mean "use the default" when merged with withDefaults.
+type Limits struct {
+	CPUTimeMs    int64 `json:"cpu_time_ms"`
+	WallTimeMs   int64 `json:"wall_time_ms"`
+	MemoryMB     int64 `json:"memory_mb"`
+	MaxProcesses int64 `json:"max_processes"`
+	FileSizeKB   int64 `json:"file_size_kb"`
+	OutputKB     int64 `json:"output_kb"`
+}
+
+var (
+	// defaultRunLimits apply to student programs unless a lesson overrides them.
+	defaultRunLimits = Limits{
+		CPUTimeMs:    2000,
+		WallTimeMs:   5000,
+		MemoryMB:     256,
+		MaxProcesses: 16,
+		FileSizeKB:   1024,
+		OutputKB:     64,
+	}
+
+	// compileLimits apply to the compiler, which needs more room than the
+	// programs it builds.
+	compileLimits = Limits{
+		CPUTimeMs:    10000,
+		WallTimeMs:   20000,
+		MemoryMB:     1024,
+		MaxProcesses: 32,
+		FileSizeKB:   64 * 1024,
+		OutputKB:     256,
+	}
+)
+
+// withDefaults fills every unset field of l from def
+func (l Limits) withDefaults(def Limits) Limits {
+	if l.CPUTimeMs <= 0 {
+		l.CPUTimeMs = def.CPUTimeMs
+	}
+	if l.WallTimeMs <= 0 {
+		l.WallTimeMs = def.WallTimeMs
+	}
+	if l.MemoryMB <= 0 {
+		l.MemoryMB = def.MemoryMB
+	}
+	if l.MaxProcesses <= 0 {
+		l.MaxProcesses = def.MaxProcesses
+	}
+	if l.FileSizeKB <= 0 {
+		l.FileSizeKB = def.FileSizeKB
+	}
+	if l.OutputKB <= 0 {
+		l.OutputKB = def.OutputKB
+	}
+	return l
+}
+
+// RunSpec describes a single process to run inside the sandbox.
+type RunSpec struct {
+	Path   string // absolute path, or a name looked up in PATH
+	Args   []string
+	Dir    string
+	Stdin  string
+	Env    []string // defaults to a minimal environment when nil
+	Limits Limits
+
+	// Binds lists further directories the program works in, such as the
+	// build directory holding its binary. Where the sandbox isolates the
+	// filesystem, only these, Dir and the system directories are visible.
+	Binds []string
+
+	// ExtraFiles are open in the program as fds 3 and up, like
+	// exec.Cmd.ExtraFiles.
+	ExtraFiles []*os.File
+
+	// MergeStderr sends stderr into Stdout, like exec.Cmd.CombinedOutput.
+	MergeStderr bool
+
+	// NoAddressSpaceLimit skips RLIMIT_AS for programs that reserve far
+	// more address space than they use, such as sanitizer builds.
+	NoAddressSpaceLimit bool
+}
+
+// ExecStatus classifies how a sandboxed process finished.
+type ExecStatus int
+
+const (
+	StatusOK ExecStatus = iota
+	StatusRuntimeError
+	StatusTimeLimit
+	StatusMemoryLimit
+	StatusSignaled
+	StatusOutputLimit
+)
+
+func (s ExecStatus) String() string {
+	switch s {
+	case StatusOK:
+		return "ok"
+	case StatusRuntimeError:
+		return "runtime error"
+	case StatusTimeLimit:
+		return "time limit exceeded"
+	case StatusMemoryLimit:
+		return "memory limit exceeded"
+	case StatusSignaled:
+		return "killed by signal"
+	case StatusOutputLimit:
+		return "output limit exceeded"
+	default:
+		return fmt.Sprintf("status %d", int(s))
+	}
+}
+
+// RunResult is the outcome of a sandboxed run.
+type RunResult struct {
+	Status   ExecStatus
+	ExitCode int
+	Signal   syscall.Signal // set when Status is StatusSignaled or a limit killed the process
+	Stdout   []byte
+	Stderr   []byte
+	Duration time.Duration
+}
+
+// Sandbox runs compiler and student processes with bounded resources.
+type Sandbox interface {
+	// Name identifies the isolation backend, for logging.
+	Name() string
+
+	// Run executes spec and reports how the process finished. An error is
+	// returned only when the process could not be run at all.
+	Run(ctx context.Context, spec RunSpec) (*RunResult, error)
+
+	// ExposesServerFiles reports whether sandboxed programs can write to
+	// the files the server keeps, such as its database and build cache.
+	ExposesServerFiles() bool
+
+	// Close releases what the sandbox holds on the host.
+	Close() error
+}
+
+// defaultEnv is the minimal environment sandboxed processes get in dir
+func defaultEnv(dir string) []string {
+	return []string{
+		"PATH=/usr/local/bin:/usr/bin:/bin",
+		"LANG=C",
+		"TMPDIR=" + dir,
+	}
+}
+
+// Errors from readRunFile for files a sandboxed program left behind
+var (
+	errNotRegularFile = errors.New("not a regular file")
+	errFileTooLarge   = errors.New("file too large")
+)
+
+// outputPipeGrace is how long outputPipe.close waits for processes a program
+// left behind that still hold the pipe open
+const outputPipeGrace = 250 * time.Millisecond
+
+// outputPipe collects what a sandboxed program writes to one of its
+// ExtraFiles, such as a harness's results, while it runs. Only the first max
+// bytes are kept; the rest is read and dropped so the program never blocks
+// on a full pipe.
+type outputPipe struct {
+	r, w *os.File
+	data chan []byte
+}
+
+func newOutputPipe(max int64) (*outputPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &outputPipe{r: r, w: w, data: make(chan []byte, 1)}
+	go func() {
+		data, _ := io.ReadAll(io.LimitReader(r, max))
+		io.Copy(io.Discard, r)
+		p.data <- data
+	}()
+	return p, nil
+}
+
+// close is called once the run is over and returns what was written
+func (p *outputPipe) close() []byte {
+	p.w.Close()
+	p.r.SetReadDeadline(time.Now().Add(outputPipeGrace))
+	data := <-p.data
+	p.r.Close()
+	return data
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// Sanitizers accepted in the "sanitizers" field of lesson.json and in
+// CodeSubmission.sanitizers
+const (
+	sanitizeAddress   = "address"
+	sanitizeUndefined = "undefined"
+	sanitizeLeak      = "leak"
+)
+
+// maxSanitizerFindings bounds the findings kept per test, since UBSan may
+// report the same mistake on every iteration of a loop.
+const maxSanitizerFindings = 20
+
+// checkerOptions is what a submission is built and graded with
+type checkerOptions struct {
+	enabled        []string // sanitizers in canonical order, empty when not sanitizing
+	valgrind       string   // valgrind binary when tests run under memcheck
+	failOnFindings bool
+}
+
+// newCheckerOptions combines the lesson's sanitizers and memory checker with
+// those requested for a single submission. valgrind is the installed
+// valgrind binary, if any.
+func newCheckerOptions(lesson *Lesson, req *pb.CodeSubmission, valgrind string) (checkerOptions, error) {
+	if err := checkSanitizers(req.Sanitizers); err != nil {
+		return checkerOptions{}, invalidField("sanitizers", err)
+	}
+	if err := checkMemoryChecker(req.MemoryChecker); err != nil {
+		return checkerOptions{}, invalidField("memory_checker", err)
+	}
+
+	want := make(map[string]bool)
+	for _, name := range append(append([]string(nil), lesson.Sanitizers...), req.Sanitizers...) {
+		want[name] = true
+	}
+	opts := checkerOptions{failOnFindings: lesson.FailOnSanitizerFindings}
+	for _, name := range []string{sanitizeAddress, sanitizeUndefined, sanitizeLeak} {
+		if want[name] {
+			opts.enabled = append(opts.enabled, name)
+		}
+	}
+
+	memoryChecker := lesson.MemoryChecker
+	if req.MemoryChecker != "" {
+		// Lessons only use valgrind where it is installed, but a submission
+		// that asks for it should not silently go unchecked.
+		if valgrind == "" {
+			return checkerOptions{}, preconditionFailed("MEMORY_CHECKER", memoryCheckerValgrind, "valgrind is not installed on this server")
+		}
+		memoryChecker = req.MemoryChecker
+	}
+	if err := checkCheckers(opts.enabled, memoryChecker); err != nil {
+		return checkerOptions{}, invalidField("sanitizers", err)
+	}
+	if memoryChecker == memoryCheckerValgrind {
+		opts.valgrind = valgrind
+	}
+	return opts, nil
+}
+
+// checkCheckers validates a combination of sanitizers and memory checker
+func checkCheckers(sanitizers []string, memoryChecker string) error {
+	if err := checkSanitizers(sanitizers); err != nil {
+		return err
+	}
+	if err := checkMemoryChecker(memoryChecker); err != nil {
+		return err
+	}
+	if memoryChecker != memoryCheckerValgrind {
+		return nil
+	}
+	for _, name := range sanitizers {
+		if name == sanitizeAddress || name == sanitizeLeak {
+			return fmt.Errorf("valgrind cannot check programs built with the %s sanitizer", name)
+		}
+	}
+	return nil
+}
+
+func checkSanitizers(names []string) error {
+	for _, name := range names {
+		switch name {
+		case sanitizeAddress, sanitizeUndefined, sanitizeLeak:
+		default:
+			return fmt.Errorf("unknown sanitizer %q (want %s, %s or %s)",
+				name, sanitizeAddress, sanitizeUndefined, sanitizeLeak)
+		}
+	}
+	return nil
+}
+
+// active reports whether any sanitizer is enabled
+func (o checkerOptions) active() bool { return len(o.enabled) > 0 }
+
+func (o checkerOptions) has(name string) bool {
+	for _, n := range o.enabled {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// compilerFlags returns the extra gcc flags for the enabled checkers.
+// AddressSanitizer already includes leak checking, and gcc rejects
+// combining it with the standalone LeakSanitizer.
+func (o checkerOptions) compilerFlags() []string {
+	if !o.active() {
+		if o.valgrind != "" {
+			// Debug info lets valgrind map its stack frames to source lines
+			return []string{"-g"}
+		}
+		return nil
+	}
+	var names []string
+	for _, name := range o.enabled {
+		if name == sanitizeLeak && o.has(sanitizeAddress) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return []string{"-g", "-fno-omit-frame-pointer", "-fsanitize=" + strings.Join(names, ",")}
+}
+
+// env returns the runtime options for the enabled sanitizers. Reports go to
+// stderr, where parseSanitizerOutput separates them from the program's own
+// output.
+func (o checkerOptions) env(l Limits) []string {
+	if !o.active() {
+		return nil
+	}
+	detectLeaks := 0
+	if o.has(sanitizeLeak) {
+		detectLeaks = 1
+	}
+	return []string{
+		// A zero exitcode keeps leak checks from exiting before stdio is
+		// flushed; runs are classified from the reports instead. RLIMIT_AS
+		// cannot be used with ASan's shadow memory, so ASan watches the
+		// resident size itself and huge mallocs fail normally.
+		fmt.Sprintf("ASAN_OPTIONS=exitcode=0:detect_leaks=%d:allocator_may_return_null=1:hard_rss_limit_mb=%d",
+			detectLeaks, l.MemoryMB),
+		"LSAN_OPTIONS=exitcode=0",
+		"UBSAN_OPTIONS=print_stacktrace=1:halt_on_error=0",
+	}
+}
+
+var (
+	sanitizerHeaderRe = regexp.MustCompile(`^==\d+==ERROR: (\w+)Sanitizer: (.*)$`)
+	sanitizerAbortRe  = regexp.MustCompile(`^==\d+==ABORTING`)
+	rssExhaustedRe    = regexp.MustCompile(`^==\d+==\w+Sanitizer: hard rss limit exhausted`)
+	runtimeErrorRe    = regexp.MustCompile(`^(.+?):(\d+):(\d+): runtime error: (.*)$`)
+	stackFrameRe      = regexp.MustCompile(`^\s*#\d+ 0x[0-9a-f]+ in (\S+) (.+?):(\d+)(?::(\d+))?$`)
+	leakRe            = regexp.MustCompile(`^(Direct|Indirect) leak of .*`)
+)
+
+// sanitizerReport is what parseSanitizerOutput found in a program's stderr
+type sanitizerReport struct {
+	findings     []*pb.SanitizerFinding
+	rssExhausted bool
+	stderr       []byte // what is left once the reports are removed
+}
+
+// parseSanitizerOutput extracts AddressSanitizer, LeakSanitizer and UBSan
+// reports from stderr. Locations in buildDir, where solution.c was
+// compiled, are shortened to plain file names.
+func parseSanitizerOutput(stderr []byte, buildDir string) sanitizerReport {
+	var report sanitizerReport
+	var rest []string
+
+	lines := strings.Split(string(stderr), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case isSeparator(line) && i+1 < len(lines) && sanitizerHeaderRe.MatchString(lines[i+1]):
+			end := reportEnd(lines, i+1)
+			report.findings = append(report.findings, parseErrorReport(lines[i+1:end], buildDir)...)
+			i = end - 1
+		case sanitizerHeaderRe.MatchString(line):
+			end := reportEnd(lines, i)
+			report.findings = append(report.findings, parseErrorReport(lines[i:end], buildDir)...)
+			i = end - 1
+		case rssExhaustedRe.MatchString(line):
+			report.rssExhausted = true
+		case runtimeErrorRe.MatchString(line):
+			end := i + 1
+			for end < len(lines) && (stackFrameRe.MatchString(lines[end]) || strings.HasPrefix(strings.TrimSpace(lines[end]), "#")) {
+				end++
+			}
+			if end < len(lines) && lines[end] == "" && end > i+1 {
+				end++
+			}
+			report.findings = append(report.findings, parseRuntimeError(lines[i:end], buildDir))
+			i = end - 1
+		default:
+			rest = append(rest, line)
+		}
+	}
+
+	if len(report.findings) > maxSanitizerFindings {
+		report.findings = report.findings[:maxSanitizerFindings]
+	}
+	report.stderr = []byte(strings.Join(rest, "\n"))
+	return report
+}
+
+func isSeparator(line string) bool {
+	return len(line) > 10 && strings.Trim(line, "=") == ""
+}
+
+// reportEnd finds the end of the ASan or LSan report starting at the
+// header line lines[start]: ASan ends its reports with ABORTING, LSan with
+// a summary.
+func reportEnd(lines []string, start int) int {
+	for i := start + 1; i < len(lines); i++ {
+		if sanitizerAbortRe.MatchString(lines[i]) {
+			return i + 1
+		}
+	}
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "SUMMARY: ") {
+			return i + 1
+		}
+	}
+	return len(lines)
+}
+
+// parseErrorReport converts one ASan or LSan report. A leak report holds
+// one finding per leaked allocation site.
+func parseErrorReport(lines []string, buildDir string) []*pb.SanitizerFinding {
+	m := sanitizerHeaderRe.FindStringSubmatch(lines[0])
+	tool, message := m[1], m[2]
+
+	// Drop ASan's shadow memory dump, which means little to a learner
+	for i, line := range lines {
+		if strings.HasPrefix(line, "SUMMARY: ") {
+			lines = lines[:i+1]
+			break
+		}
+	}
+
+	if tool != "Leak" {
+		// Registers and program counters mean little to a learner
+		if i := strings.Index(message, " at pc "); i >= 0 {
+			message = message[:i]
+		}
+		// The header is the program's own stderr, which it can forge
+		kind := "unknown"
+		if fields := strings.Fields(message); len(fields) > 0 {
+			kind = fields[0]
+		}
+		finding := &pb.SanitizerFinding{
+			Sanitizer: sanitizeAddress,
+			Kind:      kind,
+			Message:   message,
+			Report:    strings.TrimSpace(strings.Join(lines, "\n")),
+		}
+		setFindingLocation(finding, lines, buildDir)
+		return []*pb.SanitizerFinding{finding}
+	}
+
+	var findings []*pb.SanitizerFinding
+	for i := 1; i < len(lines); i++ {
+		if !leakRe.MatchString(lines[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "" {
+			end++
+		}
+		block := lines[i:end]
+		finding := &pb.SanitizerFinding{
+			Sanitizer: sanitizeLeak,
+			Kind:      strings.ToLower(strings.Fields(lines[i])[0]) + " leak",
+			Message:   strings.TrimSuffix(strings.TrimSpace(lines[i]), " allocated from:"),
+			Report:    strings.Join(block, "\n"),
+		}
+		setFindingLocation(finding, block, buildDir)
+		findings = append(findings, finding)
+		i = end - 1
+	}
+	return findings
+}
+
+// parseRuntimeError converts a UBSan "runtime error" report
+func parseRuntimeError(lines []string, buildDir string) *pb.SanitizerFinding {
+	m := runtimeErrorRe.FindStringSubmatch(lines[0])
+	line, _ := strconv.Atoi(m[2])
+	column, _ := strconv.Atoi(m[3])
+	file, _ := shortenPath(m[1], buildDir)
+
+	kind := m[4]
+	if i := strings.Index(kind, ":"); i > 0 {
+		kind = kind[:i]
+	}
+	finding := &pb.SanitizerFinding{
+		Sanitizer: sanitizeUndefined,
+		Kind:      kind,
+		Message:   m[4],
+		File:      file,
+		Line:      int32(line),
+		Column:    int32(column),
+		Report:    strings.TrimSpace(strings.Join(lines, "\n")),
+	}
+	finding.Stack, _ = parseStack(lines[1:], buildDir)
+	if len(finding.Stack) > 0 {
+		finding.Function = finding.Stack[0].Function
+	}
+	return finding
+}
+
+// setFindingLocation records the first call stack in lines and points
+// finding at it
+func setFindingLocation(finding *pb.SanitizerFinding, lines []string, buildDir string) {
+	var own int
+	finding.Stack, own = parseStack(lines, buildDir)
+	locateFinding(finding, own)
+}
+
+// parseStack returns the first call stack in lines, as printed by the
+// sanitizers, and the index of its innermost frame in the student's code,
+// or -1. Frames in shared libraries have no source location and are left
+// out.
+func parseStack(lines []string, buildDir string) ([]*pb.StackFrame, int) {
+	var stack []*pb.StackFrame
+	own := -1
+	inStack := false
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			if inStack {
+				break
+			}
+			continue
+		}
+		inStack = true
+
+		if m := stackFrameRe.FindStringSubmatch(line); m != nil {
+			file, ok := shortenPath(m[2], buildDir)
+			if ok && own < 0 {
+				own = len(stack)
+			}
+			lineNo, _ := strconv.Atoi(m[3])
+			stack = append(stack, &pb.StackFrame{
+				Function: m[1],
+				File:     file,
+				Line:     int32(lineNo),
+			})
+		}
+	}
+	return stack, own
+}
+
+// locateFinding points finding at frame own of its stack, or at the
+// innermost frame when own is negative
+func locateFinding(finding *pb.SanitizerFinding, own int) {
+	if len(finding.Stack) == 0 {
+		return
+	}
+	frame := finding.Stack[0]
+	if own >= 0 {
+		frame = finding.Stack[own]
+	}
+	finding.Function = frame.Function
+	finding.File = frame.File
+	finding.Line = frame.Line
+}
+
+// shortenPath makes path relative to buildDir, reporting whether it is
+// there, i.e. whether it is one of the submission's files. Relative paths
+// are as the compiler saw them, from buildDir.
+func shortenPath(path, buildDir string) (string, bool) {
+	abs := path
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(buildDir, abs)
+	}
+	rel, err := filepath.Rel(buildDir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return path, false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// describeFinding summarises a finding in one line, e.g.
+// "heap-buffer-overflow in main at solution.c:11"
+func describeFinding(f *pb.SanitizerFinding) string {
+	s := f.Kind
+	if f.Function != "" {
+		s += " in " + f.Function
+	}
+	if f.File != "" {
+		s += fmt.Sprintf(" at %s:%d", f.File, f.Line)
+	}
+	return s
+}
+
+// gradeSanitized adjusts a run of a sanitized program before grading and
+// returns the finding that stopped the program, if any. AddressSanitizer
+// always stops at its first error; leaks and undefined behaviour do not.
+func gradeSanitized(run *RunResult, report sanitizerReport) *pb.SanitizerFinding {
+	run.Stderr = report.stderr
+	if report.rssExhausted {
+		run.Status = StatusMemoryLimit
+		return nil
+	}
+	for _, f := range report.findings {
+		if f.Sanitizer == sanitizeAddress {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSanitizerOutput(t *testing.T) {
+	const buildDir = "/tmp/c-learning-1/build"
+
+	tests := []struct {
+		name         string
+		stderr       string
+		kinds        []string // of the findings, in order
+		file         string   // of the first finding
+		line         int32
+		rssExhausted bool
+		rest         string // stderr left over
+	}{
+		{
+			name: "heap buffer overflow",
+			stderr: `before
+=================================================================
+==6579==ERROR: AddressSanitizer: heap-buffer-overflow on address 0x602000000020 at pc 0x557b66a0d1fe bp 0x7fff6326fc90 sp 0x7fff6326fc88
+WRITE of size 4 at 0x602000000020 thread T0
+    #0 0x557b66a0d1fd in main /tmp/c-learning-1/build/solution.c:3
+    #1 0x7fc073e45249  (/lib/x86_64-linux-gnu/libc.so.6+0x27249)
+
+SUMMARY: AddressSanitizer: heap-buffer-overflow /tmp/c-learning-1/build/solution.c:3 in main
+Shadow bytes around the buggy address:
+  0x0c047fff7fb0: 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00
+==6579==ABORTING
+after`,
+			kinds: []string{"heap-buffer-overflow"},
+			file:  "solution.c",
+			line:  3,
+			rest:  "before\nafter",
+		},
+		{
+			name:   "leaks",
+			stderr: "==7==ERROR: LeakSanitizer: detected memory leaks\n\nDirect leak of 4 byte(s) in 1 object(s) allocated from:\n    #0 0x1 in malloc ../../../../src/libsanitizer/asan/asan_malloc_linux.cpp:69\n    #1 0x2 in main /tmp/c-learning-1/build/lib/list.c:9\n\nIndirect leak of 8 byte(s) in 1 object(s) allocated from:\n    #0 0x1 in malloc ../../../../src/libsanitizer/asan/asan_malloc_linux.cpp:69\n\nSUMMARY: AddressSanitizer: 12 byte(s) leaked in 2 allocation(s).",
+			kinds:  []string{"direct leak", "indirect leak"},
+			file:   "lib/list.c",
+			line:   9,
+		},
+		{
+			name:   "undefined behaviour",
+			stderr: "/tmp/c-learning-1/build/solution.c:5:7: runtime error: signed integer overflow: 2147483647 + 1 cannot be represented in type 'int'\n",
+			kinds:  []string{"signed integer overflow"},
+			file:   "solution.c",
+			line:   5,
+		},
+		{
+			name:         "rss limit",
+			stderr:       "==3==AddressSanitizer: hard rss limit exhausted (64Mb vs 65Mb)",
+			rssExhausted: true,
+		},
+
+		// Programs control their own stderr and can print anything
+		{
+			name:   "empty message",
+			stderr: "==1==ERROR: AddressSanitizer: \n",
+			kinds:  []string{"unknown"},
+		},
+		{
+			name:   "whitespace message",
+			stderr: "=================================================================\n==1==ERROR: AddressSanitizer:    \t ",
+			kinds:  []string{"unknown"},
+		},
+		{
+			name:   "message is only a pc",
+			stderr: "==1==ERROR: AddressSanitizer:  at pc 0x1\n",
+			kinds:  []string{"unknown"},
+		},
+		{
+			name:   "separator at the end",
+			stderr: "out\n=================================================================",
+			rest:   "out\n=================================================================",
+		},
+		{
+			name:   "leak header without leaks",
+			stderr: "==1==ERROR: LeakSanitizer: \nDirect leak of",
+		},
+		{
+			name:   "malformed frames",
+			stderr: "==1==ERROR: AddressSanitizer: SEGV\n    #\n    #0 0xzz in\n    #1 0x1 in main /tmp/c-learning-1/build/solution.c:99999999999999999999\n#",
+			kinds:  []string{"SEGV"},
+			file:   "solution.c",
+		},
+		{
+			name:   "runtime error with a stack and nothing else",
+			stderr: ":1:1: runtime error: \n    #0",
+			kinds:  []string{""},
+		},
+		{
+			name:   "frame outside the build",
+			stderr: "x.c:1:2: runtime error: division by zero\n    #0 0x1 in f /tmp/c-learning-1/build/../../etc/passwd:3\n",
+			kinds:  []string{"division by zero"},
+			file:   "x.c",
+			line:   1,
+		},
+		{
+			name:   "binary noise",
+			stderr: "\x00\xff==\x00==ERROR\n\n\n",
+			rest:   "\x00\xff==\x00==ERROR\n\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := parseSanitizerOutput([]byte(tt.stderr), buildDir)
+
+			var kinds []string
+			for _, f := range report.findings {
+				kinds = append(kinds, f.Kind)
+			}
+			if strings.Join(kinds, "|") != strings.Join(tt.kinds, "|") {
+				t.Errorf("kinds = %q, want %q", kinds, tt.kinds)
+			}
+			if len(report.findings) > 0 && tt.file != "" {
+				f := report.findings[0]
+				if f.File != tt.file || (tt.line != 0 && f.Line != tt.line) {
+					t.Errorf("location = %s:%d, want %s:%d", f.File, f.Line, tt.file, tt.line)
+				}
+			}
+			if report.rssExhausted != tt.rssExhausted {
+				t.Errorf("rssExhausted = %v, want %v", report.rssExhausted, tt.rssExhausted)
+			}
+			if tt.rest != "" && string(report.stderr) != tt.rest {
+				t.Errorf("stderr = %q, want %q", report.stderr, tt.rest)
+			}
+		})
+	}
+}
+
+func TestParseSanitizerOutputLimitsFindings(t *testing.T) {
+	stderr := strings.Repeat("solution.c:1:1: runtime error: x\n", 10*maxSanitizerFindings)
+	report := parseSanitizerOutput([]byte(stderr), "/build")
+	if len(report.findings) != maxSanitizerFindings {
+		t.Errorf("got %d findings, want %d", len(report.findings), maxSanitizerFindings)
+	}
+}
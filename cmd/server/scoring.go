@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// defaultPassThreshold requires every test to pass unless a lesson sets
+// "pass_threshold" in lesson.json
+const defaultPassThreshold = 100
+
+// hiddenMismatch replaces the explanation of a hidden test's wrong answer,
+// which would quote the expected output
+const hiddenMismatch = "output does not match"
+
+// checkPassThreshold validates a lesson's pass threshold, a percentage of
+// its points, and fills in the default
+func checkPassThreshold(threshold *float64) error {
+	if *threshold == 0 {
+		*threshold = defaultPassThreshold
+	}
+	if *threshold < 0 || *threshold > 100 {
+		return fmt.Errorf("pass_threshold %v must be between 0 and 100", *threshold)
+	}
+	return nil
+}
+
+// points is what tc is worth towards the lesson's score
+func (tc TestCase) points() int32 {
+	if tc.Points == nil {
+		return 1
+	}
+	return int32(*tc.Points)
+}
+
+// scoreResult records the points a test earned
+func scoreResult(result *pb.TestResult, tc TestCase) {
+	result.MaxPoints = tc.points()
+	if result.Passed {
+		result.Points = result.MaxPoints
+	}
+}
+
+// hideResult strips a hidden test's result down to its outcome: whether it
+// passed, the kind of failure and the points earned. Anything else the
+// program influences, down to its exit status, a signal or a sanitizer
+// message, could be made to spell out the test's input or expected output.
+func hideResult(result *pb.TestResult) {
+	result.Hidden = true
+	result.ExpectedOutput = ""
+	result.ActualOutput = ""
+	result.ActualStderr = ""
+	result.ExitCode = 0
+	result.Signal = ""
+	result.DurationMs = 0
+	result.SanitizerFindings = nil
+	if result.Status == pb.TestStatus_TEST_STATUS_WRONG_ANSWER {
+		result.Mismatch = hiddenMismatch
+	} else {
+		result.Mismatch = ""
+	}
+	for i, assertion := range result.Assertions {
+		result.Assertions[i] = &pb.AssertionResult{Passed: assertion.Passed}
+	}
+}
+
+// lessonScore sums the points of results and returns the percentage earned.
+// A lesson whose tests are all worth nothing scores 100 once they pass.
+func lessonScore(results []*pb.TestResult) (points, maxPoints int32, score float32) {
+	passed := true
+	for _, result := range results {
+		points += result.Points
+		maxPoints += result.MaxPoints
+		passed = passed && result.Passed
+	}
+	switch {
+	case maxPoints > 0:
+		score = 100 * float32(points) / float32(maxPoints)
+	case passed && len(results) > 0:
+		score = 100
+	}
+	return points, maxPoints, score
+}
+
+// visibleTestCases returns the tests students may see
+func visibleTestCases(cases []TestCase) []TestCase {
+	var visible []TestCase
+	for _, tc := range cases {
+		if !tc.Hidden {
+			visible = append(visible, tc)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLessonScore(t *testing.T) {
+	one, three, zero := 1, 3, 0
+	tests := []struct {
+		name      string
+		points    []*int
+		passed    []bool
+		threshold float64
+		score     float32
+		proceed   bool
+	}{
+		{"all pass", []*int{nil, nil}, []bool{true, true}, 100, 100, true},
+		{"one of two fails", []*int{nil, nil}, []bool{true, false}, 100, 50, false},
+		{"weighted test passes", []*int{&one, &three}, []bool{false, true}, 75, 75, true},
+		{"weighted test fails", []*int{&one, &three}, []bool{true, false}, 75, 25, false},
+		{"zero points ignored", []*int{&zero, &one}, []bool{false, true}, 100, 100, true},
+		{"only zero points all pass", []*int{&zero, &zero}, []bool{true, true}, 100, 100, true},
+		{"only zero points one fails", []*int{&zero, &zero}, []bool{true, false}, 100, 0, false},
+		{"below threshold", []*int{nil, nil, nil}, []bool{true, true, false}, 70, 200.0 / 3, false},
+		{"no tests", nil, nil, 100, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []*pb.TestResult
+			var wantPoints, wantMax int32
+			for i, p := range tt.points {
+				tc := TestCase{Points: p}
+				result := &pb.TestResult{Passed: tt.passed[i]}
+				scoreResult(result, tc)
+				results = append(results, result)
+				wantMax += tc.points()
+				if tt.passed[i] {
+					wantPoints += tc.points()
+				}
+			}
+			points, maxPoints, score := lessonScore(results)
+			if points != wantPoints || maxPoints != wantMax || score != tt.score {
+				t.Errorf("lessonScore = %d/%d %v, want %d/%d %v", points, maxPoints, score, wantPoints, wantMax, tt.score)
+			}
+			if proceed := float64(score) >= tt.threshold; proceed != tt.proceed {
+				t.Errorf("score %v against threshold %v proceeds = %v, want %v", score, tt.threshold, proceed, tt.proceed)
+			}
+		})
+	}
+}
+
+func TestTestCasePoints(t *testing.T) {
+	three := 3
+	if got := (TestCase{}).points(); got != 1 {
+		t.Errorf("points without a weight = %d, want 1", got)
+	}
+	if got := (TestCase{Points: &three}).points(); got != 3 {
+		t.Errorf("points = %d, want 3", got)
+	}
+}
+
+func TestCheckPassThreshold(t *testing.T) {
+	tests := []struct {
+		threshold float64
+		want      float64
+		ok        bool
+	}{
+		{0, defaultPassThreshold, true},
+		{50, 50, true},
+		{100, 100, true},
+		{-1, 0, false},
+		{100.5, 0, false},
+	}
+	for _, tt := range tests {
+		threshold := tt.threshold
+		err := checkPassThreshold(&threshold)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkPassThreshold(%v) error = %v, want ok %v", tt.threshold, err, tt.ok)
+			continue
+		}
+		if tt.ok && threshold != tt.want {
+			t.Errorf("checkPassThreshold(%v) set %v, want %v", tt.threshold, threshold, tt.want)
+		}
+	}
+}
+
+func TestHideResult(t *testing.T) {
+	result := &pb.TestResult{
+		Passed:            false,
+		Status:            pb.TestStatus_TEST_STATUS_WRONG_ANSWER,
+		ExpectedOutput:    "secret\n",
+		ActualOutput:      "guess\n",
+		ActualStderr:      "secret input\n",
+		Mismatch:          `line 1: expected "secret", got "guess"`,
+		ExitCode:          42,
+		Signal:            "SIGSEGV",
+		DurationMs:        17,
+		SanitizerFindings: []*pb.SanitizerFinding{{Message: "secret"}},
+		Assertions:        []*pb.AssertionResult{{Test: "test_secret", Passed: false, Line: 7, Message: "secret"}},
+		Points:            0,
+		MaxPoints:         2,
+	}
+	hideResult(result)
+
+	want := &pb.TestResult{
+		Hidden:     true,
+		Status:     pb.TestStatus_TEST_STATUS_WRONG_ANSWER,
+		Mismatch:   hiddenMismatch,
+		Assertions: []*pb.AssertionResult{{Passed: false}},
+		MaxPoints:  2,
+	}
+	if !proto.Equal(result, want) {
+		t.Errorf("hidden result = %v, want %v", result, want)
+	}
+
+	crashed := &pb.TestResult{Status: pb.TestStatus_TEST_STATUS_RUNTIME_ERROR, Mismatch: "exit code 42"}
+	hideResult(crashed)
+	if crashed.Mismatch != "" {
+		t.Errorf("hidden runtime error kept its mismatch %q", crashed.Mismatch)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// readReferenceSolution reads a lesson's optional solution.c from its
+// directory dir in fsys. Unlike example.c, which every student sees, it is
+// only revealed by GetSolution.
+func readReferenceSolution(fsys fs.FS, dir string, afterFailures int) (string, error) {
+	if afterFailures < 0 {
+		return "", fmt.Errorf("solution_after_failures %d must not be negative", afterFailures)
+	}
+	code, err := fs.ReadFile(fsys, path.Join(dir, solutionFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		if afterFailures > 0 {
+			return "", fmt.Errorf("solution_after_failures is set but there is no %s", solutionFile)
+		}
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(code), nil
+}
+
+// solutionUnlocked reports whether userID has completed lesson and how
+// many of their attempts at it failed, failing with FAILED_PRECONDITION
+// unless that is enough to reveal the reference solution
+func (s *server) solutionUnlocked(ctx context.Context, userID string, lesson *Lesson) (bool, int, error) {
+	progress, err := s.store.GetProgress(ctx, userID)
+	if err != nil {
+		return false, 0, err
+	}
+	completed := false
+	for _, id := range progress.CompletedLessons {
+		if id == lesson.ID {
+			completed = true
+			break
+		}
+	}
+
+	submissions, err := s.store.ListSubmissions(ctx, userID, lesson.ID)
+	if err != nil {
+		return false, 0, err
+	}
+	failures := 0
+	for _, sub := range submissions {
+		if !sub.Passed {
+			failures++
+		}
+	}
+
+	if completed || (lesson.SolutionAfterFailures > 0 && failures >= lesson.SolutionAfterFailures) {
+		return completed, failures, nil
+	}
+	msg := fmt.Sprintf("The solution to lesson %d is revealed once you complete it", lesson.ID)
+	if lesson.SolutionAfterFailures > 0 {
+		msg += fmt.Sprintf(" or after %d failed attempts (you have %d)", lesson.SolutionAfterFailures, failures)
+	}
+	return false, failures, preconditionFailed("SOLUTION_LOCKED", fmt.Sprintf("lesson %d", lesson.ID), msg)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// solutionFile is where CodeSubmission.code is written
+const solutionFile = "solution.c"
+
+// Bounds on what a single submission may contain
+const (
+	maxSourceFiles = 32
+	maxSourceBytes = 1 << 20
+)
+
+// checkSourcePath validates the path of a submitted or provided source
+// file and returns it in clean, slash-separated form. Only C sources and
+// headers inside the submission directory are accepted.
+func checkSourcePath(p string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(p, `\`, "/"))
+	switch {
+	case p == "" || strings.ContainsRune(p, 0):
+		return "", fmt.Errorf("invalid file name %q", p)
+	case path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../"):
+		return "", fmt.Errorf("file %q must be inside the submission", p)
+	case path.Ext(clean) != ".c" && path.Ext(clean) != ".h":
+		return "", fmt.Errorf("file %q is not a .c or .h file", p)
+	case clean == harnessHeaderName:
+		return "", fmt.Errorf("file name %q is reserved", p)
+	}
+	return clean, nil
+}
+
+// loadProvidedFiles reads a lesson's provided files from its directory dir
+// in fsys
+func loadProvidedFiles(fsys fs.FS, dir string, names []string) (map[string]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	files := make(map[string]string, len(names))
+	for _, name := range names {
+		p, err := checkSourcePath(name)
+		if err != nil {
+			return nil, err
+		}
+		if p == solutionFile {
+			return nil, fmt.Errorf("%s cannot be a provided file", solutionFile)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, p))
+		if err != nil {
+			return nil, err
+		}
+		files[p] = string(content)
+	}
+	return files, nil
+}
+
+func convertSourceFiles(files map[string]string) []*pb.SourceFile {
+	var result []*pb.SourceFile
+	for _, p := range sortedKeys(files) {
+		result = append(result, &pb.SourceFile{Path: p, Content: files[p]})
+	}
+	return result
+}
+
+// submittedSources returns the student's files from req by path. The code
+// field, when set, is solution.c.
+func submittedSources(req *pb.CodeSubmission) (map[string]string, error) {
+	sources := make(map[string]string)
+	if req.Code != "" || len(req.Files) == 0 {
+		sources[solutionFile] = req.Code
+	}
+	for _, f := range req.Files {
+		p, err := checkSourcePath(f.Path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := sources[p]; ok {
+			return nil, fmt.Errorf("file %q is submitted more than once", p)
+		}
+		sources[p] = f.Content
+	}
+
+	if len(sources) > maxSourceFiles {
+		return nil, fmt.Errorf("too many files: %d (at most %d)", len(sources), maxSourceFiles)
+	}
+	size := 0
+	for _, content := range sources {
+		size += len(content)
+	}
+	if size > maxSourceBytes {
+		return nil, fmt.Errorf("submission is too large: %d bytes (at most %d)", size, maxSourceBytes)
+	}
+	return sources, nil
+}
+
+// buildSources combines the student's files with the lesson's provided
+// files, which are read-only: they replace submitted files of the same
+// name.
+func (l *Lesson) buildSources(submitted map[string]string) (map[string]string, error) {
+	sources := make(map[string]string, len(submitted)+len(l.ProvidedFiles))
+	for p, content := range submitted {
+		if _, ok := l.Harnesses[p]; ok {
+			return nil, fmt.Errorf("file name %q is reserved by the lesson's tests", p)
+		}
+		sources[p] = content
+	}
+	for p, content := range l.ProvidedFiles {
+		sources[p] = content
+	}
+
+	if len(cSources(sources)) == 0 {
+		return nil, fmt.Errorf("submission has no .c files")
+	}
+	if l.Type == lessonTypeUnit {
+		// Objects are compiled into the build directory by base name
+		seen := make(map[string]string)
+		for _, p := range cSources(sources) {
+			base := path.Base(p)
+			if other, ok := seen[base]; ok {
+				return nil, fmt.Errorf("files %q and %q would compile to the same object", other, p)
+			}
+			seen[base] = p
+		}
+	}
+	return sources, nil
+}
+
+// cSources returns the .c files among sources, sorted
+func cSources(sources map[string]string) []string {
+	var files []string
+	for p := range sources {
+		if path.Ext(p) == ".c" {
+			files = append(files, p)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// objectFile is the object gcc -c writes for source in the working directory
+func objectFile(source string) string {
+	return strings.TrimSuffix(path.Base(source), ".c") + ".o"
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// User is a learner known to the server.
+type User struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Submission is one graded attempt at a lesson.
+type Submission struct {
+	ID        uint64    `json:"id"`
+	UserID    string    `json:"user_id"`
+	LessonID  int32     `json:"lesson_id"`
+	Code      string    `json:"code"`   // solution.c
+	Passed    bool      `json:"passed"` // whether the score met the lesson's pass threshold
+	Score     float32   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Files holds every submitted file by path, including solution.c
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// Store persists users, their progress and their submissions. All methods
+// are safe for concurrent use by multiple gRPC handlers.
+type Store interface {
+	// EnsureUser returns the user with the given ID, creating it on first use.
+	EnsureUser(ctx context.Context, userID string) (*User, error)
+
+	// GetProgress returns the user's progress, or a fresh progress record
+	// starting at lesson 1 if the user has none yet.
+	GetProgress(ctx context.Context, userID string) (*UserProgress, error)
+
+	// UpdateProgress atomically applies fn to the user's progress and stores
+	// the result. If fn returns an error nothing is written.
+	UpdateProgress(ctx context.Context, userID string, fn func(*UserProgress) error) (*UserProgress, error)
+
+	// AddSubmission stores sub and assigns its ID.
+	AddSubmission(ctx context.Context, sub *Submission) error
+
+	// ListSubmissions returns the user's submissions for a lesson, oldest first.
+	ListSubmissions(ctx context.Context, userID string, lessonID int32) ([]*Submission, error)
+
+	Close() error
+}
+
+// newUserProgress returns the progress of a user who has not completed anything
+func newUserProgress() *UserProgress {
+	return &UserProgress{
+		CurrentLesson:    1,
+		CompletedLessons: []int32{},
+	}
+}
+
+// openStore opens the store backend selected by kind: "memory" or "bolt"
+func openStore(kind, path string) (Store, error) {
+	switch kind {
+	case "memory":
+		return newMemoryStore(), nil
+	case "bolt":
+		return openBoltStore(path)
+	default:
+		return nil, fmt.Errorf("unknown store %q (want memory or bolt)", kind)
+	}
+}
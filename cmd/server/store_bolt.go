@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	metaBucket        = []byte("meta")
+	usersBucket       = []byte("users")
+	progressBucket    = []byte("progress")
+	submissionsBucket = []byte("submissions")
+
+	schemaVersionKey = []byte("schema_version")
+)
+
+// boltMigrations upgrade the database schema one version at a time. The
+// schema version stored in the meta bucket is the number of migrations
+// applied; append new migrations and never edit existing ones.
+var boltMigrations = []func(tx *bolt.Tx) error{
+	// 1: users and progress keyed by user ID, submissions in a nested
+	// bucket per user keyed by a big-endian sequence number.
+	func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{usersBucket, progressBucket, submissionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// boltStore keeps users, progress and submissions in an embedded bbolt
+// database. bbolt serialises write transactions, which makes
+// UpdateProgress atomic.
+type boltStore struct {
+	db *bolt.DB
+}
+
+func openBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %v", path, err)
+	}
+
+	s := &boltStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate applies every migration newer than the stored schema version
+func (s *boltStore) migrate() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+
+		var version uint64
+		if v := meta.Get(schemaVersionKey); v != nil {
+			version = binary.BigEndian.Uint64(v)
+		}
+		if version > uint64(len(boltMigrations)) {
+			return fmt.Errorf("database schema version %d is newer than this server supports (%d)",
+				version, len(boltMigrations))
+		}
+
+		for i := version; i < uint64(len(boltMigrations)); i++ {
+			if err := boltMigrations[i](tx); err != nil {
+				return fmt.Errorf("failed to migrate database to version %d: %v", i+1, err)
+			}
+		}
+		return meta.Put(schemaVersionKey, encodeUint64(uint64(len(boltMigrations))))
+	})
+}
+
+func (s *boltStore) EnsureUser(ctx context.Context, userID string) (*User, error) {
+	var user *User
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		user, err = ensureBoltUser(tx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *boltStore) GetProgress(ctx context.Context, userID string) (*UserProgress, error) {
+	progress := newUserProgress()
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(progressBucket).Get([]byte(userID))
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(data, progress)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress for %s: %v", userID, err)
+	}
+	return progress, nil
+}
+
+func (s *boltStore) UpdateProgress(ctx context.Context, userID string, fn func(*UserProgress) error) (*UserProgress, error) {
+	progress := newUserProgress()
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if _, err := ensureBoltUser(tx, userID); err != nil {
+			return err
+		}
+
+		bucket := tx.Bucket(progressBucket)
+		if data := bucket.Get([]byte(userID)); data != nil {
+			if err := json.Unmarshal(data, progress); err != nil {
+				return err
+			}
+		}
+		if err := fn(progress); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(userID), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (s *boltStore) AddSubmission(ctx context.Context, sub *Submission) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if _, err := ensureBoltUser(tx, sub.UserID); err != nil {
+			return err
+		}
+
+		root := tx.Bucket(submissionsBucket)
+		id, err := root.NextSequence()
+		if err != nil {
+			return err
+		}
+		bucket, err := root.CreateBucketIfNotExists([]byte(sub.UserID))
+		if err != nil {
+			return err
+		}
+
+		sub.ID = id
+		data, err := json.Marshal(sub)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(encodeUint64(id), data)
+	})
+}
+
+func (s *boltStore) ListSubmissions(ctx context.Context, userID string, lessonID int32) ([]*Submission, error) {
+	var result []*Submission
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(submissionsBucket).Bucket([]byte(userID))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, data []byte) error {
+			var sub Submission
+			if err := json.Unmarshal(data, &sub); err != nil {
+				return err
+			}
+			if sub.LessonID == lessonID {
+				result = append(result, &sub)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions for %s: %v", userID, err)
+	}
+	return result, nil
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+// ensureBoltUser loads the user record inside tx, creating it if missing
+func ensureBoltUser(tx *bolt.Tx, userID string) (*User, error) {
+	bucket := tx.Bucket(usersBucket)
+	if data := bucket.Get([]byte(userID)); data != nil {
+		var user User
+		if err := json.Unmarshal(data, &user); err != nil {
+			return nil, err
+		}
+		return &user, nil
+	}
+
+	user := &User{ID: userID, CreatedAt: time.Now()}
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := bucket.Put([]byte(userID), data); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
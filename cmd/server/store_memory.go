@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore keeps everything in process memory. It is meant for tests and
+// throwaway servers; all data is lost on restart.
+type memoryStore struct {
+	mu          sync.RWMutex
+	users       map[string]*User
+	progress    map[string]*UserProgress
+	submissions map[string][]*Submission
+	nextID      uint64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		users:       make(map[string]*User),
+		progress:    make(map[string]*UserProgress),
+		submissions: make(map[string][]*Submission),
+	}
+}
+
+func (m *memoryStore) EnsureUser(ctx context.Context, userID string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		user = &User{ID: userID, CreatedAt: time.Now()}
+		m.users[userID] = user
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *memoryStore) GetProgress(ctx context.Context, userID string) (*UserProgress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	progress, ok := m.progress[userID]
+	if !ok {
+		return newUserProgress(), nil
+	}
+	return copyProgress(progress), nil
+}
+
+func (m *memoryStore) UpdateProgress(ctx context.Context, userID string, fn func(*UserProgress) error) (*UserProgress, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	progress := newUserProgress()
+	if existing, ok := m.progress[userID]; ok {
+		progress = copyProgress(existing)
+	}
+	if err := fn(progress); err != nil {
+		return nil, err
+	}
+
+	if _, ok := m.users[userID]; !ok {
+		m.users[userID] = &User{ID: userID, CreatedAt: time.Now()}
+	}
+	m.progress[userID] = progress
+	return copyProgress(progress), nil
+}
+
+func (m *memoryStore) AddSubmission(ctx context.Context, sub *Submission) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[sub.UserID]; !ok {
+		m.users[sub.UserID] = &User{ID: sub.UserID, CreatedAt: time.Now()}
+	}
+	m.nextID++
+	sub.ID = m.nextID
+	copied := *sub
+	m.submissions[sub.UserID] = append(m.submissions[sub.UserID], &copied)
+	return nil
+}
+
+func (m *memoryStore) ListSubmissions(ctx context.Context, userID string, lessonID int32) ([]*Submission, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*Submission
+	for _, sub := range m.submissions[userID] {
+		if sub.LessonID == lessonID {
+			copied := *sub
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
+
+// copyProgress returns a deep copy so callers cannot mutate stored state
+func copyProgress(p *UserProgress) *UserProgress {
+	copied := *p
+	copied.CompletedLessons = append([]int32{}, p.CompletedLessons...)
+	if p.HintsUsed != nil {
+		copied.HintsUsed = make(map[int32]int32, len(p.HintsUsed))
+		for id, n := range p.HintsUsed {
+			copied.HintsUsed[id] = n
+		}
+	}
+	return &copied
+}
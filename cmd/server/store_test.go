@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// testStores opens every store backend, each empty
+func testStores(t *testing.T) map[string]Store {
+	bolt, err := openBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores := map[string]Store{"memory": newMemoryStore(), "bolt": bolt}
+	t.Cleanup(func() {
+		for _, s := range stores {
+			s.Close()
+		}
+	})
+	return stores
+}
+
+func TestStoreProgress(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			progress, err := s.GetProgress(ctx, "alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if progress.CurrentLesson != 1 || len(progress.CompletedLessons) != 0 {
+				t.Errorf("progress of a new user = %+v, want lesson 1 and nothing completed", progress)
+			}
+
+			updated, err := s.UpdateProgress(ctx, "alice", func(p *UserProgress) error {
+				p.CurrentLesson = 2
+				p.CompletedLessons = append(p.CompletedLessons, 1)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The result belongs to the caller
+			updated.CompletedLessons[0] = 99
+
+			progress, err = s.GetProgress(ctx, "alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if progress.CurrentLesson != 2 || !reflect.DeepEqual(progress.CompletedLessons, []int32{1}) {
+				t.Errorf("progress = %+v, want lesson 2 with lesson 1 completed", progress)
+			}
+
+			failed := errors.New("rejected")
+			_, err = s.UpdateProgress(ctx, "alice", func(p *UserProgress) error {
+				p.CurrentLesson = 3
+				return failed
+			})
+			if !errors.Is(err, failed) {
+				t.Errorf("UpdateProgress error = %v, want %v", err, failed)
+			}
+			if progress, _ := s.GetProgress(ctx, "alice"); progress.CurrentLesson != 2 {
+				t.Errorf("failed update was written: current lesson %d", progress.CurrentLesson)
+			}
+
+			if progress, _ := s.GetProgress(ctx, "bob"); progress.CurrentLesson != 1 {
+				t.Errorf("another user's progress leaked: current lesson %d", progress.CurrentLesson)
+			}
+		})
+	}
+}
+
+func TestStoreSubmissions(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			subs := []*Submission{
+				{UserID: "alice", LessonID: 1, Code: "first"},
+				{UserID: "alice", LessonID: 2, Code: "other lesson"},
+				{UserID: "bob", LessonID: 1, Code: "other user"},
+				{UserID: "alice", LessonID: 1, Code: "second", Passed: true},
+			}
+			var lastID uint64
+			for _, sub := range subs {
+				if err := s.AddSubmission(ctx, sub); err != nil {
+					t.Fatal(err)
+				}
+				if sub.ID <= lastID {
+					t.Errorf("submission ID %d after %d, want increasing IDs", sub.ID, lastID)
+				}
+				lastID = sub.ID
+			}
+
+			got, err := s.ListSubmissions(ctx, "alice", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var codes []string
+			for _, sub := range got {
+				codes = append(codes, sub.Code)
+			}
+			if strings.Join(codes, ",") != "first,second" {
+				t.Errorf("submissions = %q, want first and second, oldest first", codes)
+			}
+			if len(got) == 2 && (got[0].Passed || !got[1].Passed) {
+				t.Errorf("passed = %v, %v; want false, true", got[0].Passed, got[1].Passed)
+			}
+
+			if got, _ := s.ListSubmissions(ctx, "carol", 1); len(got) != 0 {
+				t.Errorf("unknown user has %d submissions", len(got))
+			}
+		})
+	}
+}
+
+func TestStoreEnsureUser(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			first, err := s.EnsureUser(ctx, "alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := s.EnsureUser(ctx, "alice")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first.ID != "alice" || !again.CreatedAt.Equal(first.CreatedAt) {
+				t.Errorf("EnsureUser twice = %+v, %+v; want the same user", first, again)
+			}
+		})
+	}
+}
+
+func TestBoltStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	s, err := openBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateProgress(ctx, "alice", func(p *UserProgress) error {
+		p.CurrentLesson = 4
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddSubmission(ctx, &Submission{UserID: "alice", LessonID: 3, Code: "kept"}); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	s, err = openBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if progress, _ := s.GetProgress(ctx, "alice"); progress.CurrentLesson != 4 {
+		t.Errorf("current lesson after reopening = %d, want 4", progress.CurrentLesson)
+	}
+	if subs, _ := s.ListSubmissions(ctx, "alice", 3); len(subs) != 1 || subs[0].Code != "kept" {
+		t.Errorf("submissions after reopening = %v, want the one added", subs)
+	}
+}
+
+func TestBoltStoreMigrations(t *testing.T) {
+	dir := t.TempDir()
+
+	// A database from before schema versions has no buckets at all
+	path := filepath.Join(dir, "old.db")
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	s, err := openBoltStore(path)
+	if err != nil {
+		t.Fatalf("opening an unversioned database: %v", err)
+	}
+	err = s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{usersBucket, progressBucket, submissionsBucket} {
+			if tx.Bucket(name) == nil {
+				t.Errorf("bucket %s missing after migration", name)
+			}
+		}
+		if v := readSchemaVersion(tx); v != uint64(len(boltMigrations)) {
+			t.Errorf("schema version = %d, want %d", v, len(boltMigrations))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// A database written by a newer server must be left alone
+	path = filepath.Join(dir, "new.db")
+	db, err = bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucket(metaBucket)
+		if err != nil {
+			return err
+		}
+		return meta.Put(schemaVersionKey, encodeUint64(uint64(len(boltMigrations)+1)))
+	})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := openBoltStore(path); err == nil {
+		s.Close()
+		t.Error("opened a database with a newer schema version")
+	}
+}
+
+func readSchemaVersion(tx *bolt.Tx) uint64 {
+	meta := tx.Bucket(metaBucket)
+	if meta == nil {
+		return 0
+	}
+	v := meta.Get(schemaVersionKey)
+	if len(v) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(v)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/afshin-deriv/c-learning/proto"
+)
+
+// memoryCheckerValgrind in the "memory_checker" field of lesson.json, or in
+// CodeSubmission.memory_checker, runs every test under valgrind memcheck
+const memoryCheckerValgrind = "valgrind"
+
+const (
+	// maxValgrindReportBytes bounds how much of the XML report is parsed
+	maxValgrindReportBytes = 4 << 20
+
+	// valgrindSlowdown scales a lesson's time limits for runs under
+	// memcheck, which typically makes programs 10-50 times slower
+	valgrindSlowdown = 10
+)
+
+func checkMemoryChecker(name string) error {
+	switch name {
+	case "", memoryCheckerValgrind:
+		return nil
+	default:
+		return fmt.Errorf("unknown memory checker %q (want %s)", name, memoryCheckerValgrind)
+	}
+}
+
+// valgrindSpec wraps the run of binary in valgrind memcheck, writing its
+// XML report to the pipe report. A file in the working directory could be
+// planted or swapped by the program.
+func valgrindSpec(valgrind string, spec RunSpec, report *os.File) RunSpec {
+	spec.ExtraFiles = append(spec.ExtraFiles, report)
+	spec.Args = append([]string{
+		"--tool=memcheck",
+		"--xml=yes",
+		fmt.Sprintf("--xml-fd=%d", 2+len(spec.ExtraFiles)),
+		"--leak-check=full",
+		"--show-leak-kinds=definite,indirect,possible",
+		"--track-origins=yes",
+		"--child-silent-after-fork=yes",
+		"--quiet",
+		spec.Path,
+	}, spec.Args...)
+	spec.Path = valgrind
+	spec.Limits.CPUTimeMs *= valgrindSlowdown
+	spec.Limits.WallTimeMs *= valgrindSlowdown
+	// valgrind reserves far more address space than the program uses
+	spec.NoAddressSpaceLimit = true
+	return spec
+}
+
+// valgrindError mirrors an <error> element of valgrind's XML output
+type valgrindError struct {
+	Kind  string `xml:"kind"`
+	What  string `xml:"what"`
+	XWhat struct {
+		Text string `xml:"text"`
+	} `xml:"xwhat"`
+	AuxWhat []string        `xml:"auxwhat"`
+	Stacks  []valgrindStack `xml:"stack"`
+}
+
+type valgrindStack struct {
+	Frames []struct {
+		Fn   string `xml:"fn"`
+		Dir  string `xml:"dir"`
+		File string `xml:"file"`
+		Line int32  `xml:"line"`
+	} `xml:"frame"`
+}
+
+// parseValgrindReport parses the memcheck report of a test run. A run
+// killed by a limit leaves the XML unfinished, so everything up to the cut
+// is kept.
+func parseValgrindReport(report []byte, buildDir string) []*pb.SanitizerFinding {
+	// The program shares the pipe with valgrind, so the report is
+	// untrusted: read no more than valgrind would plausibly write
+	var findings []*pb.SanitizerFinding
+	decoder := xml.NewDecoder(io.LimitReader(bytes.NewReader(report), maxValgrindReportBytes))
+	for len(findings) < maxSanitizerFindings {
+		// Stops at the end of the report, or where it was cut short
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "error" {
+			continue
+		}
+
+		var e valgrindError
+		if err := decoder.DecodeElement(&e, &start); err != nil {
+			break
+		}
+		findings = append(findings, valgrindFinding(e, buildDir))
+	}
+	return findings
+}
+
+// valgrindFinding converts one memcheck error, such as an invalid write or
+// a definitely lost block
+func valgrindFinding(e valgrindError, buildDir string) *pb.SanitizerFinding {
+	message := e.What
+	if message == "" {
+		message = e.XWhat.Text
+	}
+
+	finding := &pb.SanitizerFinding{
+		Sanitizer: memoryCheckerValgrind,
+		Kind:      e.Kind,
+		Message:   message,
+	}
+	own := -1
+	if len(e.Stacks) > 0 {
+		for _, frame := range e.Stacks[0].Frames {
+			if frame.File == "" {
+				continue
+			}
+			file, ok := shortenPath(filepath.Join(frame.Dir, frame.File), buildDir)
+			if ok && own < 0 {
+				own = len(finding.Stack)
+			}
+			finding.Stack = append(finding.Stack, &pb.StackFrame{
+				Function: frame.Fn,
+				File:     file,
+				Line:     frame.Line,
+			})
+		}
+	}
+	locateFinding(finding, own)
+
+	// Rebuild the text report the way valgrind prints it: each stack after
+	// the first belongs to the auxiliary line before it
+	var report strings.Builder
+	report.WriteString(message + "\n")
+	for i, stack := range e.Stacks {
+		if i > 0 && i <= len(e.AuxWhat) {
+			report.WriteString(" " + e.AuxWhat[i-1] + "\n")
+		}
+		for j, frame := range stack.Frames {
+			label := "by"
+			if j == 0 {
+				label = "at"
+			}
+			if frame.File != "" {
+				fmt.Fprintf(&report, "   %s %s (%s:%d)\n", label, frame.Fn, frame.File, frame.Line)
+			} else {
+				fmt.Fprintf(&report, "   %s %s\n", label, frame.Fn)
+			}
+		}
+	}
+	if len(e.AuxWhat) >= len(e.Stacks) && len(e.Stacks) > 0 {
+		for _, aux := range e.AuxWhat[len(e.Stacks)-1:] {
+			report.WriteString(" " + aux + "\n")
+		}
+	}
+	finding.Report = strings.TrimRight(report.String(), "\n")
+	return finding
+}
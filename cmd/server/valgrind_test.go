@@ -0,0 +1,126 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const valgrindInvalidWrite = `<error>
+  <unique>0x0</unique>
+  <tid>1</tid>
+  <kind>InvalidWrite</kind>
+  <what>Invalid write of size 4</what>
+  <stack>
+    <frame><ip>0x1</ip><fn>main</fn><dir>/tmp/c-learning-1/build</dir><file>solution.c</file><line>7</line></frame>
+  </stack>
+  <auxwhat>Address 0x4a8b050 is 0 bytes after a block of size 16 alloc'd</auxwhat>
+  <stack>
+    <frame><ip>0x2</ip><fn>malloc</fn></frame>
+    <frame><ip>0x3</ip><fn>main</fn><dir>/tmp/c-learning-1/build</dir><file>solution.c</file><line>5</line></frame>
+  </stack>
+</error>`
+
+func TestParseValgrindReport(t *testing.T) {
+	const buildDir = "/tmp/c-learning-1/build"
+	const header = `<?xml version="1.0"?><valgrindoutput><protocolversion>4</protocolversion>`
+
+	tests := []struct {
+		name  string
+		xml   string // empty for no report at all
+		kinds []string
+		file  string // of the first finding
+		line  int32
+	}{
+		{name: "no report"},
+		{
+			name:  "invalid write",
+			xml:   header + valgrindInvalidWrite + `</valgrindoutput>`,
+			kinds: []string{"InvalidWrite"},
+			file:  "solution.c",
+			line:  7,
+		},
+		{
+			name:  "leak with xwhat",
+			xml:   header + `<error><kind>Leak_DefinitelyLost</kind><xwhat><text>4 bytes are definitely lost</text></xwhat><stack><frame><fn>malloc</fn></frame><frame><fn>f</fn><dir>/tmp/c-learning-1/build/lib</dir><file>list.c</file><line>3</line></frame></stack></error></valgrindoutput>`,
+			kinds: []string{"Leak_DefinitelyLost"},
+			file:  "lib/list.c",
+			line:  3,
+		},
+		{
+			name:  "cut short by a limit",
+			xml:   header + valgrindInvalidWrite + `<error><kind>InvalidRead</kind><what>Inval`,
+			kinds: []string{"InvalidWrite"},
+		},
+
+		// The program can overwrite the report, as it is in its working
+		// directory
+		{name: "empty", xml: " "},
+		{name: "not xml", xml: "\x00\xff<<<>>>&&&"},
+		{
+			name:  "empty error",
+			xml:   header + `<error></error></valgrindoutput>`,
+			kinds: []string{""},
+		},
+		{
+			name:  "more auxwhats than stacks",
+			xml:   header + `<error><kind>X</kind><auxwhat>a</auxwhat><auxwhat>b</auxwhat></error>`,
+			kinds: []string{"X"},
+		},
+		{
+			name:  "stacks without frames",
+			xml:   header + `<error><kind>X</kind><stack/><stack/><auxwhat>a</auxwhat></error>`,
+			kinds: []string{"X"},
+		},
+		{
+			name:  "bad line number",
+			xml:   header + `<error><kind>X</kind><stack><frame><fn>f</fn><file>solution.c</file><line>not a number</line></frame></stack></error>`,
+			kinds: nil,
+		},
+		{
+			name:  "frame outside the build",
+			xml:   header + `<error><kind>X</kind><stack><frame><fn>f</fn><dir>/etc</dir><file>passwd</file><line>1</line></frame></stack></error>`,
+			kinds: []string{"X"},
+			file:  "/etc/passwd",
+			line:  1,
+		},
+		{
+			name:  "errors nested in errors",
+			xml:   header + strings.Repeat("<error>", 1000) + `<kind>X</kind>` + strings.Repeat("</error>", 1000),
+			kinds: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := parseValgrindReport([]byte(tt.xml), buildDir)
+			var kinds []string
+			for _, f := range findings {
+				kinds = append(kinds, f.Kind)
+			}
+			if strings.Join(kinds, "|") != strings.Join(tt.kinds, "|") {
+				t.Errorf("kinds = %q, want %q", kinds, tt.kinds)
+			}
+			if len(findings) > 0 && tt.file != "" {
+				f := findings[0]
+				if f.File != tt.file || f.Line != tt.line {
+					t.Errorf("location = %s:%d, want %s:%d", f.File, f.Line, tt.file, tt.line)
+				}
+			}
+		})
+	}
+}
+
+func TestParseValgrindReportLimits(t *testing.T) {
+	report := `<valgrindoutput>` + strings.Repeat(valgrindInvalidWrite, 10*maxSanitizerFindings)
+	findings := parseValgrindReport([]byte(report), "/tmp/c-learning-1/build")
+	if len(findings) != maxSanitizerFindings {
+		t.Errorf("got %d findings, want %d", len(findings), maxSanitizerFindings)
+	}
+
+	// Reports larger than valgrind would write are cut off
+	huge := `<valgrindoutput><error><kind>X</kind><what>` + strings.Repeat("a", maxValgrindReportBytes) + `</what></error>`
+	findings = parseValgrindReport([]byte(huge), "/tmp/c-learning-1/build")
+	if len(findings) != 0 {
+		t.Errorf("got %d findings from an oversized report, want 0", len(findings))
+	}
+}
@@ -0,0 +1,11 @@
+// Package lessons bundles the default curriculum into the server, so that
+// it can run without a lessons directory next to it
+package lessons
+
+import "embed"
+
+// FS holds the track directories, laid out as they are on disk. A new
+// track must be added to the pattern below.
+//
+//go:embed fundamentals
+var FS embed.FS
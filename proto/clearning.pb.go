@@ -7,6 +7,7 @@
 package proto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -20,12 +21,189 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DiagnosticSeverity int32
+
+const (
+	DiagnosticSeverity_DIAGNOSTIC_SEVERITY_UNSPECIFIED DiagnosticSeverity = 0
+	DiagnosticSeverity_DIAGNOSTIC_SEVERITY_ERROR       DiagnosticSeverity = 1
+	DiagnosticSeverity_DIAGNOSTIC_SEVERITY_WARNING     DiagnosticSeverity = 2
+	DiagnosticSeverity_DIAGNOSTIC_SEVERITY_NOTE        DiagnosticSeverity = 3
+)
+
+// Enum value maps for DiagnosticSeverity.
+var (
+	DiagnosticSeverity_name = map[int32]string{
+		0: "DIAGNOSTIC_SEVERITY_UNSPECIFIED",
+		1: "DIAGNOSTIC_SEVERITY_ERROR",
+		2: "DIAGNOSTIC_SEVERITY_WARNING",
+		3: "DIAGNOSTIC_SEVERITY_NOTE",
+	}
+	DiagnosticSeverity_value = map[string]int32{
+		"DIAGNOSTIC_SEVERITY_UNSPECIFIED": 0,
+		"DIAGNOSTIC_SEVERITY_ERROR":       1,
+		"DIAGNOSTIC_SEVERITY_WARNING":     2,
+		"DIAGNOSTIC_SEVERITY_NOTE":        3,
+	}
+)
+
+func (x DiagnosticSeverity) Enum() *DiagnosticSeverity {
+	p := new(DiagnosticSeverity)
+	*p = x
+	return p
+}
+
+func (x DiagnosticSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiagnosticSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_clearning_proto_enumTypes[0].Descriptor()
+}
+
+func (DiagnosticSeverity) Type() protoreflect.EnumType {
+	return &file_proto_v1_clearning_proto_enumTypes[0]
+}
+
+func (x DiagnosticSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiagnosticSeverity.Descriptor instead.
+func (DiagnosticSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{0}
+}
+
+// TestStatus tells apart the ways a single test run can finish.
+type TestStatus int32
+
+const (
+	TestStatus_TEST_STATUS_UNSPECIFIED           TestStatus = 0
+	TestStatus_TEST_STATUS_PASSED                TestStatus = 1
+	TestStatus_TEST_STATUS_WRONG_ANSWER          TestStatus = 2
+	TestStatus_TEST_STATUS_RUNTIME_ERROR         TestStatus = 3
+	TestStatus_TEST_STATUS_TIME_LIMIT_EXCEEDED   TestStatus = 4
+	TestStatus_TEST_STATUS_MEMORY_LIMIT_EXCEEDED TestStatus = 5
+	TestStatus_TEST_STATUS_KILLED_BY_SIGNAL      TestStatus = 6
+	TestStatus_TEST_STATUS_OUTPUT_LIMIT_EXCEEDED TestStatus = 7
+	// A sanitizer stopped the program, or reported a finding in a lesson that
+	// fails on findings.
+	TestStatus_TEST_STATUS_SANITIZER_ERROR TestStatus = 8
+)
+
+// Enum value maps for TestStatus.
+var (
+	TestStatus_name = map[int32]string{
+		0: "TEST_STATUS_UNSPECIFIED",
+		1: "TEST_STATUS_PASSED",
+		2: "TEST_STATUS_WRONG_ANSWER",
+		3: "TEST_STATUS_RUNTIME_ERROR",
+		4: "TEST_STATUS_TIME_LIMIT_EXCEEDED",
+		5: "TEST_STATUS_MEMORY_LIMIT_EXCEEDED",
+		6: "TEST_STATUS_KILLED_BY_SIGNAL",
+		7: "TEST_STATUS_OUTPUT_LIMIT_EXCEEDED",
+		8: "TEST_STATUS_SANITIZER_ERROR",
+	}
+	TestStatus_value = map[string]int32{
+		"TEST_STATUS_UNSPECIFIED":           0,
+		"TEST_STATUS_PASSED":                1,
+		"TEST_STATUS_WRONG_ANSWER":          2,
+		"TEST_STATUS_RUNTIME_ERROR":         3,
+		"TEST_STATUS_TIME_LIMIT_EXCEEDED":   4,
+		"TEST_STATUS_MEMORY_LIMIT_EXCEEDED": 5,
+		"TEST_STATUS_KILLED_BY_SIGNAL":      6,
+		"TEST_STATUS_OUTPUT_LIMIT_EXCEEDED": 7,
+		"TEST_STATUS_SANITIZER_ERROR":       8,
+	}
+)
+
+func (x TestStatus) Enum() *TestStatus {
+	p := new(TestStatus)
+	*p = x
+	return p
+}
+
+func (x TestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_clearning_proto_enumTypes[1].Descriptor()
+}
+
+func (TestStatus) Type() protoreflect.EnumType {
+	return &file_proto_v1_clearning_proto_enumTypes[1]
+}
+
+func (x TestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TestStatus.Descriptor instead.
+func (TestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{1}
+}
+
+type LessonStatus int32
+
+const (
+	LessonStatus_LESSON_STATUS_UNSPECIFIED LessonStatus = 0
+	LessonStatus_LESSON_STATUS_LOCKED      LessonStatus = 1
+	LessonStatus_LESSON_STATUS_AVAILABLE   LessonStatus = 2
+	LessonStatus_LESSON_STATUS_COMPLETED   LessonStatus = 3
+)
+
+// Enum value maps for LessonStatus.
+var (
+	LessonStatus_name = map[int32]string{
+		0: "LESSON_STATUS_UNSPECIFIED",
+		1: "LESSON_STATUS_LOCKED",
+		2: "LESSON_STATUS_AVAILABLE",
+		3: "LESSON_STATUS_COMPLETED",
+	}
+	LessonStatus_value = map[string]int32{
+		"LESSON_STATUS_UNSPECIFIED": 0,
+		"LESSON_STATUS_LOCKED":      1,
+		"LESSON_STATUS_AVAILABLE":   2,
+		"LESSON_STATUS_COMPLETED":   3,
+	}
+)
+
+func (x LessonStatus) Enum() *LessonStatus {
+	p := new(LessonStatus)
+	*p = x
+	return p
+}
+
+func (x LessonStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LessonStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_clearning_proto_enumTypes[2].Descriptor()
+}
+
+func (LessonStatus) Type() protoreflect.EnumType {
+	return &file_proto_v1_clearning_proto_enumTypes[2]
+}
+
+func (x LessonStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LessonStatus.Descriptor instead.
+func (LessonStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{2}
+}
+
 type LessonRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	LessonId int32 `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	// User whose completed lessons unlock this one; the x-user-id metadata is
+	// used when empty.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
 func (x *LessonRequest) Reset() {
@@ -58,41 +236,1978 @@ func (*LessonRequest) Descriptor() ([]byte, []int) {
 	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *LessonRequest) GetLessonId() int32 {
+func (x *LessonRequest) GetLessonId() int32 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *LessonRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type LessonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LessonId           int32       `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	Title              string      `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description        string      `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ExampleCode        string      `protobuf:"bytes,4,opt,name=example_code,json=exampleCode,proto3" json:"example_code,omitempty"`
+	LearningObjectives []string    `protobuf:"bytes,5,rep,name=learning_objectives,json=learningObjectives,proto3" json:"learning_objectives,omitempty"`
+	TestCases          []*TestCase `protobuf:"bytes,6,rep,name=test_cases,json=testCases,proto3" json:"test_cases,omitempty"`
+	// How submissions are compiled, for building locally the same way.
+	Build *BuildConfig `protobuf:"bytes,7,opt,name=build,proto3" json:"build,omitempty"`
+	// Read-only files the lesson compiles with every submission.
+	ProvidedFiles []*SourceFile `protobuf:"bytes,8,rep,name=provided_files,json=providedFiles,proto3" json:"provided_files,omitempty"`
+	// Number of hidden tests, which are left out of test_cases.
+	HiddenTestCount int32 `protobuf:"varint,9,opt,name=hidden_test_count,json=hiddenTestCount,proto3" json:"hidden_test_count,omitempty"`
+	// Percentage of the lesson's points needed to complete it.
+	PassThreshold float32 `protobuf:"fixed32,10,opt,name=pass_threshold,json=passThreshold,proto3" json:"pass_threshold,omitempty"`
+	// Number of hints GetHint can reveal, and the percentage each one
+	// deducts from the score.
+	HintCount   int32   `protobuf:"varint,11,opt,name=hint_count,json=hintCount,proto3" json:"hint_count,omitempty"`
+	HintPenalty float32 `protobuf:"fixed32,12,opt,name=hint_penalty,json=hintPenalty,proto3" json:"hint_penalty,omitempty"`
+	// Whether GetSolution can reveal a reference solution, and after how
+	// many failed attempts it does so for users who have not completed the
+	// lesson; 0 means only after completing it.
+	HasSolution           bool  `protobuf:"varint,13,opt,name=has_solution,json=hasSolution,proto3" json:"has_solution,omitempty"`
+	SolutionAfterFailures int32 `protobuf:"varint,14,opt,name=solution_after_failures,json=solutionAfterFailures,proto3" json:"solution_after_failures,omitempty"`
+}
+
+func (x *LessonResponse) Reset() {
+	*x = LessonResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LessonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LessonResponse) ProtoMessage() {}
+
+func (x *LessonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LessonResponse.ProtoReflect.Descriptor instead.
+func (*LessonResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LessonResponse) GetLessonId() int32 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *LessonResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LessonResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LessonResponse) GetExampleCode() string {
+	if x != nil {
+		return x.ExampleCode
+	}
+	return ""
+}
+
+func (x *LessonResponse) GetLearningObjectives() []string {
+	if x != nil {
+		return x.LearningObjectives
+	}
+	return nil
+}
+
+func (x *LessonResponse) GetTestCases() []*TestCase {
+	if x != nil {
+		return x.TestCases
+	}
+	return nil
+}
+
+func (x *LessonResponse) GetBuild() *BuildConfig {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *LessonResponse) GetProvidedFiles() []*SourceFile {
+	if x != nil {
+		return x.ProvidedFiles
+	}
+	return nil
+}
+
+func (x *LessonResponse) GetHiddenTestCount() int32 {
+	if x != nil {
+		return x.HiddenTestCount
+	}
+	return 0
+}
+
+func (x *LessonResponse) GetPassThreshold() float32 {
+	if x != nil {
+		return x.PassThreshold
+	}
+	return 0
+}
+
+func (x *LessonResponse) GetHintCount() int32 {
+	if x != nil {
+		return x.HintCount
+	}
+	return 0
+}
+
+func (x *LessonResponse) GetHintPenalty() float32 {
+	if x != nil {
+		return x.HintPenalty
+	}
+	return 0
+}
+
+func (x *LessonResponse) GetHasSolution() bool {
+	if x != nil {
+		return x.HasSolution
+	}
+	return false
+}
+
+func (x *LessonResponse) GetSolutionAfterFailures() int32 {
+	if x != nil {
+		return x.SolutionAfterFailures
+	}
+	return 0
+}
+
+type BuildConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "gcc" or "clang".
+	Compiler string `protobuf:"bytes,1,opt,name=compiler,proto3" json:"compiler,omitempty"`
+	// C standard such as "c99"; empty for the compiler's default.
+	Std string `protobuf:"bytes,2,opt,name=std,proto3" json:"std,omitempty"`
+	// Compiler flags, including the default warning flags.
+	Flags []string `protobuf:"bytes,3,rep,name=flags,proto3" json:"flags,omitempty"`
+	// Libraries to link, as -l flags.
+	Libs []string `protobuf:"bytes,4,rep,name=libs,proto3" json:"libs,omitempty"`
+	// Preprocessor defines, as NAME or NAME=VALUE.
+	Defines []string `protobuf:"bytes,5,rep,name=defines,proto3" json:"defines,omitempty"`
+}
+
+func (x *BuildConfig) Reset() {
+	*x = BuildConfig{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildConfig) ProtoMessage() {}
+
+func (x *BuildConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildConfig.ProtoReflect.Descriptor instead.
+func (*BuildConfig) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuildConfig) GetCompiler() string {
+	if x != nil {
+		return x.Compiler
+	}
+	return ""
+}
+
+func (x *BuildConfig) GetStd() string {
+	if x != nil {
+		return x.Std
+	}
+	return ""
+}
+
+func (x *BuildConfig) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *BuildConfig) GetLibs() []string {
+	if x != nil {
+		return x.Libs
+	}
+	return nil
+}
+
+func (x *BuildConfig) GetDefines() []string {
+	if x != nil {
+		return x.Defines
+	}
+	return nil
+}
+
+type TestCase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input          string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	ExpectedOutput string `protobuf:"bytes,2,opt,name=expected_output,json=expectedOutput,proto3" json:"expected_output,omitempty"`
+	Description    string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// How output is compared with expected_output: "trimmed" (default),
+	// "exact", "whitespace", "regex", "numeric", "unordered_lines" or "contains".
+	Matcher string `protobuf:"bytes,4,opt,name=matcher,proto3" json:"matcher,omitempty"`
+	// Command-line arguments passed to the program.
+	Args []string          `protobuf:"bytes,5,rep,name=args,proto3" json:"args,omitempty"`
+	Env  map[string]string `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Files placed in the working directory before the program runs.
+	Files map[string]string `protobuf:"bytes,7,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Expected exit status; absent when the program must exit with 0.
+	ExpectedExitCode *int32 `protobuf:"varint,8,opt,name=expected_exit_code,json=expectedExitCode,proto3,oneof" json:"expected_exit_code,omitempty"`
+	// Expected stderr; absent when stderr is not checked.
+	ExpectedStderr *string `protobuf:"bytes,9,opt,name=expected_stderr,json=expectedStderr,proto3,oneof" json:"expected_stderr,omitempty"`
+	// Files the program must leave in its working directory.
+	ExpectedFiles map[string]string `protobuf:"bytes,10,rep,name=expected_files,json=expectedFiles,proto3" json:"expected_files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Harness the student's functions are linked against, for unit lessons.
+	Harness string `protobuf:"bytes,11,opt,name=harness,proto3" json:"harness,omitempty"`
+	// Points the test is worth towards the lesson's score.
+	Points int32 `protobuf:"varint,12,opt,name=points,proto3" json:"points,omitempty"`
+	// How far numbers may be from the expected ones with the "numeric"
+	// matcher.
+	Tolerance float64 `protobuf:"fixed64,13,opt,name=tolerance,proto3" json:"tolerance,omitempty"`
+}
+
+func (x *TestCase) Reset() {
+	*x = TestCase{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase) ProtoMessage() {}
+
+func (x *TestCase) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
+func (*TestCase) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TestCase) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *TestCase) GetExpectedOutput() string {
+	if x != nil {
+		return x.ExpectedOutput
+	}
+	return ""
+}
+
+func (x *TestCase) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TestCase) GetMatcher() string {
+	if x != nil {
+		return x.Matcher
+	}
+	return ""
+}
+
+func (x *TestCase) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *TestCase) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *TestCase) GetFiles() map[string]string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *TestCase) GetExpectedExitCode() int32 {
+	if x != nil && x.ExpectedExitCode != nil {
+		return *x.ExpectedExitCode
+	}
+	return 0
+}
+
+func (x *TestCase) GetExpectedStderr() string {
+	if x != nil && x.ExpectedStderr != nil {
+		return *x.ExpectedStderr
+	}
+	return ""
+}
+
+func (x *TestCase) GetExpectedFiles() map[string]string {
+	if x != nil {
+		return x.ExpectedFiles
+	}
+	return nil
+}
+
+func (x *TestCase) GetHarness() string {
+	if x != nil {
+		return x.Harness
+	}
+	return ""
+}
+
+func (x *TestCase) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *TestCase) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+type CodeSubmission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LessonId int32 `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	// Content of solution.c.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	// User the submission is attributed to. When empty, the "x-user-id"
+	// request metadata is used instead.
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Sanitizers to build with in addition to the lesson's own: "address",
+	// "undefined" or "leak".
+	Sanitizers []string `protobuf:"bytes,4,rep,name=sanitizers,proto3" json:"sanitizers,omitempty"`
+	// Set to "valgrind" to run each test under valgrind memcheck. Cannot be
+	// combined with the address or leak sanitizer.
+	MemoryChecker string `protobuf:"bytes,5,opt,name=memory_checker,json=memoryChecker,proto3" json:"memory_checker,omitempty"`
+	// Source files by path relative to the submission, for programs made of
+	// several files. When code is also set it is solution.c.
+	Files []*SourceFile `protobuf:"bytes,6,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *CodeSubmission) Reset() {
+	*x = CodeSubmission{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodeSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodeSubmission) ProtoMessage() {}
+
+func (x *CodeSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodeSubmission.ProtoReflect.Descriptor instead.
+func (*CodeSubmission) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CodeSubmission) GetLessonId() int32 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *CodeSubmission) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CodeSubmission) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CodeSubmission) GetSanitizers() []string {
+	if x != nil {
+		return x.Sanitizers
+	}
+	return nil
+}
+
+func (x *CodeSubmission) GetMemoryChecker() string {
+	if x != nil {
+		return x.MemoryChecker
+	}
+	return ""
+}
+
+func (x *CodeSubmission) GetFiles() []*SourceFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type SourceFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path    string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SourceFile) Reset() {
+	*x = SourceFile{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceFile) ProtoMessage() {}
+
+func (x *SourceFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceFile.ProtoReflect.Descriptor instead.
+func (*SourceFile) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SourceFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SourceFile) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ValidationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsValid     bool          `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	TestResults []*TestResult `protobuf:"bytes,2,rep,name=test_results,json=testResults,proto3" json:"test_results,omitempty"`
+	Feedback    string        `protobuf:"bytes,3,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	CanProceed  bool          `protobuf:"varint,4,opt,name=can_proceed,json=canProceed,proto3" json:"can_proceed,omitempty"`
+	// Compiler diagnostics, including warnings when compilation succeeded.
+	Diagnostics []*Diagnostic `protobuf:"bytes,5,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// Command line of each compiler step, exactly as the grader ran it.
+	CompileCommands []string `protobuf:"bytes,6,rep,name=compile_commands,json=compileCommands,proto3" json:"compile_commands,omitempty"`
+	// Percentage of the lesson's points earned, 0 to 100, less
+	// hint_deduction.
+	Score     float32 `protobuf:"fixed32,7,opt,name=score,proto3" json:"score,omitempty"`
+	Points    int32   `protobuf:"varint,8,opt,name=points,proto3" json:"points,omitempty"`
+	MaxPoints int32   `protobuf:"varint,9,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+	// Percentage of points needed for can_proceed. Hints do not count
+	// against it.
+	PassThreshold float32 `protobuf:"fixed32,10,opt,name=pass_threshold,json=passThreshold,proto3" json:"pass_threshold,omitempty"`
+	// Set when the submission did not compile; no tests were run and
+	// diagnostics explain why.
+	CompileError bool `protobuf:"varint,11,opt,name=compile_error,json=compileError,proto3" json:"compile_error,omitempty"`
+	// Hints the user had revealed, and the percentage taken off the score
+	// for them.
+	HintsUsed     int32   `protobuf:"varint,12,opt,name=hints_used,json=hintsUsed,proto3" json:"hints_used,omitempty"`
+	HintDeduction float32 `protobuf:"fixed32,13,opt,name=hint_deduction,json=hintDeduction,proto3" json:"hint_deduction,omitempty"`
+}
+
+func (x *ValidationResponse) Reset() {
+	*x = ValidationResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationResponse) ProtoMessage() {}
+
+func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
+func (*ValidationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValidationResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetTestResults() []*TestResult {
+	if x != nil {
+		return x.TestResults
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+func (x *ValidationResponse) GetCanProceed() bool {
+	if x != nil {
+		return x.CanProceed
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetCompileCommands() []string {
+	if x != nil {
+		return x.CompileCommands
+	}
+	return nil
+}
+
+func (x *ValidationResponse) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ValidationResponse) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *ValidationResponse) GetMaxPoints() int32 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+func (x *ValidationResponse) GetPassThreshold() float32 {
+	if x != nil {
+		return x.PassThreshold
+	}
+	return 0
+}
+
+func (x *ValidationResponse) GetCompileError() bool {
+	if x != nil {
+		return x.CompileError
+	}
+	return false
+}
+
+func (x *ValidationResponse) GetHintsUsed() int32 {
+	if x != nil {
+		return x.HintsUsed
+	}
+	return 0
+}
+
+func (x *ValidationResponse) GetHintDeduction() float32 {
+	if x != nil {
+		return x.HintDeduction
+	}
+	return 0
+}
+
+// Diagnostic is a single compiler message. Lines and columns are 1-based;
+// zero means the compiler did not report a location.
+type Diagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File   string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Line   int32  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column int32  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	// End of the highlighted range on the same line, exclusive.
+	EndColumn int32              `protobuf:"varint,4,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"`
+	Severity  DiagnosticSeverity `protobuf:"varint,5,opt,name=severity,proto3,enum=clearning.DiagnosticSeverity" json:"severity,omitempty"`
+	Message   string             `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Warning option that triggered the diagnostic, e.g. "-Wunused-variable".
+	Option string   `protobuf:"bytes,7,opt,name=option,proto3" json:"option,omitempty"`
+	Fixits []*FixIt `protobuf:"bytes,8,rep,name=fixits,proto3" json:"fixits,omitempty"`
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostic.ProtoReflect.Descriptor instead.
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Diagnostic) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetEndColumn() int32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+func (x *Diagnostic) GetSeverity() DiagnosticSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return DiagnosticSeverity_DIAGNOSTIC_SEVERITY_UNSPECIFIED
+}
+
+func (x *Diagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetOption() string {
+	if x != nil {
+		return x.Option
+	}
+	return ""
+}
+
+func (x *Diagnostic) GetFixits() []*FixIt {
+	if x != nil {
+		return x.Fixits
+	}
+	return nil
+}
+
+// FixIt suggests replacing the source between start and end with replacement.
+type FixIt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Line        int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Column      int32  `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	EndLine     int32  `protobuf:"varint,3,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	EndColumn   int32  `protobuf:"varint,4,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"`
+	Replacement string `protobuf:"bytes,5,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (x *FixIt) Reset() {
+	*x = FixIt{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixIt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixIt) ProtoMessage() {}
+
+func (x *FixIt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixIt.ProtoReflect.Descriptor instead.
+func (*FixIt) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FixIt) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *FixIt) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *FixIt) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *FixIt) GetEndColumn() int32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+func (x *FixIt) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+type TestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Passed              bool       `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	TestCaseDescription string     `protobuf:"bytes,2,opt,name=test_case_description,json=testCaseDescription,proto3" json:"test_case_description,omitempty"`
+	ActualOutput        string     `protobuf:"bytes,3,opt,name=actual_output,json=actualOutput,proto3" json:"actual_output,omitempty"`
+	ExpectedOutput      string     `protobuf:"bytes,4,opt,name=expected_output,json=expectedOutput,proto3" json:"expected_output,omitempty"`
+	Status              TestStatus `protobuf:"varint,5,opt,name=status,proto3,enum=clearning.TestStatus" json:"status,omitempty"`
+	ExitCode            int32      `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Name of the terminating signal, e.g. "SIGSEGV", when the program was killed.
+	Signal     string `protobuf:"bytes,7,opt,name=signal,proto3" json:"signal,omitempty"`
+	DurationMs int64  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Explanation of the first difference for a wrong answer.
+	Mismatch     string `protobuf:"bytes,9,opt,name=mismatch,proto3" json:"mismatch,omitempty"`
+	ActualStderr string `protobuf:"bytes,10,opt,name=actual_stderr,json=actualStderr,proto3" json:"actual_stderr,omitempty"`
+	// Assertions reported by the test harness, in order, for unit lessons.
+	Assertions []*AssertionResult `protobuf:"bytes,11,rep,name=assertions,proto3" json:"assertions,omitempty"`
+	// Problems reported by AddressSanitizer, UBSan or LeakSanitizer.
+	SanitizerFindings []*SanitizerFinding `protobuf:"bytes,12,rep,name=sanitizer_findings,json=sanitizerFindings,proto3" json:"sanitizer_findings,omitempty"`
+	// Hidden tests report their outcome only: no input, output or expected
+	// output.
+	Hidden bool `protobuf:"varint,13,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	// Points earned, either all of max_points or none.
+	Points    int32 `protobuf:"varint,14,opt,name=points,proto3" json:"points,omitempty"`
+	MaxPoints int32 `protobuf:"varint,15,opt,name=max_points,json=maxPoints,proto3" json:"max_points,omitempty"`
+}
+
+func (x *TestResult) Reset() {
+	*x = TestResult{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestResult) ProtoMessage() {}
+
+func (x *TestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestResult.ProtoReflect.Descriptor instead.
+func (*TestResult) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TestResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *TestResult) GetTestCaseDescription() string {
+	if x != nil {
+		return x.TestCaseDescription
+	}
+	return ""
+}
+
+func (x *TestResult) GetActualOutput() string {
+	if x != nil {
+		return x.ActualOutput
+	}
+	return ""
+}
+
+func (x *TestResult) GetExpectedOutput() string {
+	if x != nil {
+		return x.ExpectedOutput
+	}
+	return ""
+}
+
+func (x *TestResult) GetStatus() TestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TestStatus_TEST_STATUS_UNSPECIFIED
+}
+
+func (x *TestResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *TestResult) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+func (x *TestResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TestResult) GetMismatch() string {
+	if x != nil {
+		return x.Mismatch
+	}
+	return ""
+}
+
+func (x *TestResult) GetActualStderr() string {
+	if x != nil {
+		return x.ActualStderr
+	}
+	return ""
+}
+
+func (x *TestResult) GetAssertions() []*AssertionResult {
+	if x != nil {
+		return x.Assertions
+	}
+	return nil
+}
+
+func (x *TestResult) GetSanitizerFindings() []*SanitizerFinding {
+	if x != nil {
+		return x.SanitizerFindings
+	}
+	return nil
+}
+
+func (x *TestResult) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *TestResult) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *TestResult) GetMaxPoints() int32 {
+	if x != nil {
+		return x.MaxPoints
+	}
+	return 0
+}
+
+// SanitizerFinding is one report from a sanitizer the program was built with,
+// or from valgrind memcheck.
+type SanitizerFinding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Checker that reported it: "address", "undefined", "leak" or "valgrind".
+	Sanitizer string `protobuf:"bytes,1,opt,name=sanitizer,proto3" json:"sanitizer,omitempty"`
+	// Short classification, e.g. "heap-buffer-overflow" or "signed integer overflow".
+	Kind    string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Location in the student's code, when the report has one.
+	File     string `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	Line     int32  `protobuf:"varint,5,opt,name=line,proto3" json:"line,omitempty"`
+	Column   int32  `protobuf:"varint,6,opt,name=column,proto3" json:"column,omitempty"`
+	Function string `protobuf:"bytes,7,opt,name=function,proto3" json:"function,omitempty"`
+	// The report as printed by the sanitizer.
+	Report string `protobuf:"bytes,8,opt,name=report,proto3" json:"report,omitempty"`
+	// Call stack of the problem, innermost frame first.
+	Stack []*StackFrame `protobuf:"bytes,9,rep,name=stack,proto3" json:"stack,omitempty"`
+}
+
+func (x *SanitizerFinding) Reset() {
+	*x = SanitizerFinding{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SanitizerFinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SanitizerFinding) ProtoMessage() {}
+
+func (x *SanitizerFinding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SanitizerFinding.ProtoReflect.Descriptor instead.
+func (*SanitizerFinding) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SanitizerFinding) GetSanitizer() string {
+	if x != nil {
+		return x.Sanitizer
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SanitizerFinding) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *SanitizerFinding) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetReport() string {
+	if x != nil {
+		return x.Report
+	}
+	return ""
+}
+
+func (x *SanitizerFinding) GetStack() []*StackFrame {
+	if x != nil {
+		return x.Stack
+	}
+	return nil
+}
+
+type StackFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Function string `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	// File name, relative to the submission for the student's own files.
+	File string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Line int32  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+}
+
+func (x *StackFrame) Reset() {
+	*x = StackFrame{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StackFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StackFrame) ProtoMessage() {}
+
+func (x *StackFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StackFrame.ProtoReflect.Descriptor instead.
+func (*StackFrame) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StackFrame) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *StackFrame) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *StackFrame) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+// AssertionResult is one assertion checked by a unit-test harness.
+type AssertionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the harness test function the assertion is in.
+	Test   string `protobuf:"bytes,1,opt,name=test,proto3" json:"test,omitempty"`
+	Passed bool   `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Line of the assertion in the harness source.
+	Line    int32  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *AssertionResult) Reset() {
+	*x = AssertionResult{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssertionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssertionResult) ProtoMessage() {}
+
+func (x *AssertionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssertionResult.ProtoReflect.Descriptor instead.
+func (*AssertionResult) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssertionResult) GetTest() string {
+	if x != nil {
+		return x.Test
+	}
+	return ""
+}
+
+func (x *AssertionResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *AssertionResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *AssertionResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ValidationEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*ValidationEvent_CompileStarted
+	//	*ValidationEvent_CompileFinished
+	//	*ValidationEvent_TestStarted
+	//	*ValidationEvent_TestFinished
+	//	*ValidationEvent_Summary
+	//	*ValidationEvent_Queued
+	Event isValidationEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *ValidationEvent) Reset() {
+	*x = ValidationEvent{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationEvent) ProtoMessage() {}
+
+func (x *ValidationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationEvent.ProtoReflect.Descriptor instead.
+func (*ValidationEvent) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *ValidationEvent) GetEvent() isValidationEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetCompileStarted() *CompileStarted {
+	if x, ok := x.GetEvent().(*ValidationEvent_CompileStarted); ok {
+		return x.CompileStarted
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetCompileFinished() *CompileFinished {
+	if x, ok := x.GetEvent().(*ValidationEvent_CompileFinished); ok {
+		return x.CompileFinished
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetTestStarted() *TestStarted {
+	if x, ok := x.GetEvent().(*ValidationEvent_TestStarted); ok {
+		return x.TestStarted
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetTestFinished() *TestFinished {
+	if x, ok := x.GetEvent().(*ValidationEvent_TestFinished); ok {
+		return x.TestFinished
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetSummary() *ValidationResponse {
+	if x, ok := x.GetEvent().(*ValidationEvent_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *ValidationEvent) GetQueued() *Queued {
+	if x, ok := x.GetEvent().(*ValidationEvent_Queued); ok {
+		return x.Queued
+	}
+	return nil
+}
+
+type isValidationEvent_Event interface {
+	isValidationEvent_Event()
+}
+
+type ValidationEvent_CompileStarted struct {
+	CompileStarted *CompileStarted `protobuf:"bytes,1,opt,name=compile_started,json=compileStarted,proto3,oneof"`
+}
+
+type ValidationEvent_CompileFinished struct {
+	CompileFinished *CompileFinished `protobuf:"bytes,2,opt,name=compile_finished,json=compileFinished,proto3,oneof"`
+}
+
+type ValidationEvent_TestStarted struct {
+	TestStarted *TestStarted `protobuf:"bytes,3,opt,name=test_started,json=testStarted,proto3,oneof"`
+}
+
+type ValidationEvent_TestFinished struct {
+	TestFinished *TestFinished `protobuf:"bytes,4,opt,name=test_finished,json=testFinished,proto3,oneof"`
+}
+
+type ValidationEvent_Summary struct {
+	Summary *ValidationResponse `protobuf:"bytes,5,opt,name=summary,proto3,oneof"`
+}
+
+type ValidationEvent_Queued struct {
+	Queued *Queued `protobuf:"bytes,6,opt,name=queued,proto3,oneof"`
+}
+
+func (*ValidationEvent_CompileStarted) isValidationEvent_Event() {}
+
+func (*ValidationEvent_CompileFinished) isValidationEvent_Event() {}
+
+func (*ValidationEvent_TestStarted) isValidationEvent_Event() {}
+
+func (*ValidationEvent_TestFinished) isValidationEvent_Event() {}
+
+func (*ValidationEvent_Summary) isValidationEvent_Event() {}
+
+func (*ValidationEvent_Queued) isValidationEvent_Event() {}
+
+// Queued is sent while the submission waits for a free grader, whenever its
+// place in the queue changes.
+type Queued struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 1 when it is next.
+	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *Queued) Reset() {
+	*x = Queued{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Queued) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Queued) ProtoMessage() {}
+
+func (x *Queued) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Queued.ProtoReflect.Descriptor instead.
+func (*Queued) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Queued) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type CompileStarted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CompileStarted) Reset() {
+	*x = CompileStarted{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileStarted) ProtoMessage() {}
+
+func (x *CompileStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileStarted.ProtoReflect.Descriptor instead.
+func (*CompileStarted) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{15}
+}
+
+type CompileFinished struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Compiler output, including warnings when compilation succeeded.
+	Output      string        `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	Diagnostics []*Diagnostic `protobuf:"bytes,3,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	// Command line of each compiler step, exactly as the grader ran it.
+	Commands []string `protobuf:"bytes,4,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Whether the result came from the build cache instead of the compiler.
+	Cached bool `protobuf:"varint,5,opt,name=cached,proto3" json:"cached,omitempty"`
+}
+
+func (x *CompileFinished) Reset() {
+	*x = CompileFinished{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompileFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileFinished) ProtoMessage() {}
+
+func (x *CompileFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileFinished.ProtoReflect.Descriptor instead.
+func (*CompileFinished) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CompileFinished) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CompileFinished) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *CompileFinished) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+func (x *CompileFinished) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *CompileFinished) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+type TestStarted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index       int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Total       int32  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *TestStarted) Reset() {
+	*x = TestStarted{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestStarted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestStarted) ProtoMessage() {}
+
+func (x *TestStarted) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestStarted.ProtoReflect.Descriptor instead.
+func (*TestStarted) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TestStarted) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TestStarted) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TestStarted) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type TestFinished struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index  int32       `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Result *TestResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *TestFinished) Reset() {
+	*x = TestFinished{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestFinished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestFinished) ProtoMessage() {}
+
+func (x *TestFinished) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestFinished.ProtoReflect.Descriptor instead.
+func (*TestFinished) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TestFinished) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TestFinished) GetResult() *TestResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type NextLessonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *NextLessonRequest) Reset() {
+	*x = NextLessonRequest{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextLessonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextLessonRequest) ProtoMessage() {}
+
+func (x *NextLessonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextLessonRequest.ProtoReflect.Descriptor instead.
+func (*NextLessonRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NextLessonRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type NextLessonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LessonId int32  `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	Title    string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// Set, with no lesson, once every lesson is completed.
+	CurriculumCompleted bool `protobuf:"varint,3,opt,name=curriculum_completed,json=curriculumCompleted,proto3" json:"curriculum_completed,omitempty"`
+}
+
+func (x *NextLessonResponse) Reset() {
+	*x = NextLessonResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextLessonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextLessonResponse) ProtoMessage() {}
+
+func (x *NextLessonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextLessonResponse.ProtoReflect.Descriptor instead.
+func (*NextLessonResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NextLessonResponse) GetLessonId() int32 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *NextLessonResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NextLessonResponse) GetCurriculumCompleted() bool {
+	if x != nil {
+		return x.CurriculumCompleted
+	}
+	return false
+}
+
+type HintRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LessonId int32 `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	// User the hint is revealed to; the x-user-id metadata is used when empty.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *HintRequest) Reset() {
+	*x = HintRequest{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintRequest) ProtoMessage() {}
+
+func (x *HintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintRequest.ProtoReflect.Descriptor instead.
+func (*HintRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *HintRequest) GetLessonId() int32 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *HintRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type HintResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Every hint revealed to the user so far, in order.
+	Hints      []string `protobuf:"bytes,1,rep,name=hints,proto3" json:"hints,omitempty"`
+	TotalHints int32    `protobuf:"varint,2,opt,name=total_hints,json=totalHints,proto3" json:"total_hints,omitempty"`
+	// Whether this request revealed a new hint; false once all are revealed.
+	Revealed bool `protobuf:"varint,3,opt,name=revealed,proto3" json:"revealed,omitempty"`
+	// Percentage deducted from the score for each hint revealed.
+	HintPenalty float32 `protobuf:"fixed32,4,opt,name=hint_penalty,json=hintPenalty,proto3" json:"hint_penalty,omitempty"`
+}
+
+func (x *HintResponse) Reset() {
+	*x = HintResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HintResponse) ProtoMessage() {}
+
+func (x *HintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HintResponse.ProtoReflect.Descriptor instead.
+func (*HintResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HintResponse) GetHints() []string {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+func (x *HintResponse) GetTotalHints() int32 {
+	if x != nil {
+		return x.TotalHints
+	}
+	return 0
+}
+
+func (x *HintResponse) GetRevealed() bool {
+	if x != nil {
+		return x.Revealed
+	}
+	return false
+}
+
+func (x *HintResponse) GetHintPenalty() float32 {
 	if x != nil {
-		return x.LessonId
+		return x.HintPenalty
 	}
 	return 0
 }
 
-type LessonResponse struct {
+type SolutionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LessonId           int32       `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
-	Title              string      `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description        string      `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	ExampleCode        string      `protobuf:"bytes,4,opt,name=example_code,json=exampleCode,proto3" json:"example_code,omitempty"`
-	LearningObjectives []string    `protobuf:"bytes,5,rep,name=learning_objectives,json=learningObjectives,proto3" json:"learning_objectives,omitempty"`
-	TestCases          []*TestCase `protobuf:"bytes,6,rep,name=test_cases,json=testCases,proto3" json:"test_cases,omitempty"`
+	LessonId int32 `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	// User whose attempts unlock the solution; the x-user-id metadata is used
+	// when empty.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *LessonResponse) Reset() {
-	*x = LessonResponse{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[1]
+func (x *SolutionRequest) Reset() {
+	*x = SolutionRequest{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LessonResponse) String() string {
+func (x *SolutionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LessonResponse) ProtoMessage() {}
+func (*SolutionRequest) ProtoMessage() {}
 
-func (x *LessonResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[1]
+func (x *SolutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -103,78 +2218,53 @@ func (x *LessonResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LessonResponse.ProtoReflect.Descriptor instead.
-func (*LessonResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SolutionRequest.ProtoReflect.Descriptor instead.
+func (*SolutionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *LessonResponse) GetLessonId() int32 {
+func (x *SolutionRequest) GetLessonId() int32 {
 	if x != nil {
 		return x.LessonId
 	}
 	return 0
 }
 
-func (x *LessonResponse) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *LessonResponse) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *LessonResponse) GetExampleCode() string {
+func (x *SolutionRequest) GetUserId() string {
 	if x != nil {
-		return x.ExampleCode
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *LessonResponse) GetLearningObjectives() []string {
-	if x != nil {
-		return x.LearningObjectives
-	}
-	return nil
-}
-
-func (x *LessonResponse) GetTestCases() []*TestCase {
-	if x != nil {
-		return x.TestCases
-	}
-	return nil
-}
-
-type TestCase struct {
+type SolutionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Input          string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
-	ExpectedOutput string `protobuf:"bytes,2,opt,name=expected_output,json=expectedOutput,proto3" json:"expected_output,omitempty"`
-	Description    string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The reference solution.c.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Whether the user has completed the lesson; if not, failed attempts
+	// unlocked the solution.
+	Completed      bool  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	FailedAttempts int32 `protobuf:"varint,3,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
 }
 
-func (x *TestCase) Reset() {
-	*x = TestCase{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[2]
+func (x *SolutionResponse) Reset() {
+	*x = SolutionResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestCase) String() string {
+func (x *SolutionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestCase) ProtoMessage() {}
+func (*SolutionResponse) ProtoMessage() {}
 
-func (x *TestCase) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[2]
+func (x *SolutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -185,56 +2275,55 @@ func (x *TestCase) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestCase.ProtoReflect.Descriptor instead.
-func (*TestCase) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use SolutionResponse.ProtoReflect.Descriptor instead.
+func (*SolutionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *TestCase) GetInput() string {
+func (x *SolutionResponse) GetCode() string {
 	if x != nil {
-		return x.Input
+		return x.Code
 	}
 	return ""
 }
 
-func (x *TestCase) GetExpectedOutput() string {
+func (x *SolutionResponse) GetCompleted() bool {
 	if x != nil {
-		return x.ExpectedOutput
+		return x.Completed
 	}
-	return ""
+	return false
 }
 
-func (x *TestCase) GetDescription() string {
+func (x *SolutionResponse) GetFailedAttempts() int32 {
 	if x != nil {
-		return x.Description
+		return x.FailedAttempts
 	}
-	return ""
+	return 0
 }
 
-type CodeSubmission struct {
+type ProgressRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LessonId int32  `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
-	Code     string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *CodeSubmission) Reset() {
-	*x = CodeSubmission{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[3]
+func (x *ProgressRequest) Reset() {
+	*x = ProgressRequest{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CodeSubmission) String() string {
+func (x *ProgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CodeSubmission) ProtoMessage() {}
+func (*ProgressRequest) ProtoMessage() {}
 
-func (x *CodeSubmission) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[3]
+func (x *ProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,51 +2334,43 @@ func (x *CodeSubmission) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CodeSubmission.ProtoReflect.Descriptor instead.
-func (*CodeSubmission) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *CodeSubmission) GetLessonId() int32 {
-	if x != nil {
-		return x.LessonId
-	}
-	return 0
+// Deprecated: Use ProgressRequest.ProtoReflect.Descriptor instead.
+func (*ProgressRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *CodeSubmission) GetCode() string {
+func (x *ProgressRequest) GetUserId() string {
 	if x != nil {
-		return x.Code
+		return x.UserId
 	}
 	return ""
 }
 
-type ValidationResponse struct {
+type ProgressResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsValid     bool          `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
-	TestResults []*TestResult `protobuf:"bytes,2,rep,name=test_results,json=testResults,proto3" json:"test_results,omitempty"`
-	Feedback    string        `protobuf:"bytes,3,opt,name=feedback,proto3" json:"feedback,omitempty"`
-	CanProceed  bool          `protobuf:"varint,4,opt,name=can_proceed,json=canProceed,proto3" json:"can_proceed,omitempty"`
+	CurrentLesson        int32   `protobuf:"varint,1,opt,name=current_lesson,json=currentLesson,proto3" json:"current_lesson,omitempty"`
+	CompletedLessons     []int32 `protobuf:"varint,2,rep,packed,name=completed_lessons,json=completedLessons,proto3" json:"completed_lessons,omitempty"`
+	CompletionPercentage float32 `protobuf:"fixed32,3,opt,name=completion_percentage,json=completionPercentage,proto3" json:"completion_percentage,omitempty"`
 }
 
-func (x *ValidationResponse) Reset() {
-	*x = ValidationResponse{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[4]
+func (x *ProgressResponse) Reset() {
+	*x = ProgressResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidationResponse) String() string {
+func (x *ProgressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidationResponse) ProtoMessage() {}
+func (*ProgressResponse) ProtoMessage() {}
 
-func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[4]
+func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,65 +2381,56 @@ func (x *ValidationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidationResponse.ProtoReflect.Descriptor instead.
-func (*ValidationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ProgressResponse.ProtoReflect.Descriptor instead.
+func (*ProgressResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ValidationResponse) GetIsValid() bool {
+func (x *ProgressResponse) GetCurrentLesson() int32 {
 	if x != nil {
-		return x.IsValid
+		return x.CurrentLesson
 	}
-	return false
+	return 0
 }
 
-func (x *ValidationResponse) GetTestResults() []*TestResult {
+func (x *ProgressResponse) GetCompletedLessons() []int32 {
 	if x != nil {
-		return x.TestResults
+		return x.CompletedLessons
 	}
 	return nil
 }
 
-func (x *ValidationResponse) GetFeedback() string {
-	if x != nil {
-		return x.Feedback
-	}
-	return ""
-}
-
-func (x *ValidationResponse) GetCanProceed() bool {
+func (x *ProgressResponse) GetCompletionPercentage() float32 {
 	if x != nil {
-		return x.CanProceed
+		return x.CompletionPercentage
 	}
-	return false
+	return 0
 }
 
-type TestResult struct {
+type ListLessonsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Passed              bool   `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
-	TestCaseDescription string `protobuf:"bytes,2,opt,name=test_case_description,json=testCaseDescription,proto3" json:"test_case_description,omitempty"`
-	ActualOutput        string `protobuf:"bytes,3,opt,name=actual_output,json=actualOutput,proto3" json:"actual_output,omitempty"`
-	ExpectedOutput      string `protobuf:"bytes,4,opt,name=expected_output,json=expectedOutput,proto3" json:"expected_output,omitempty"`
+	// When set, every lesson carries this user's status.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *TestResult) Reset() {
-	*x = TestResult{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[5]
+func (x *ListLessonsRequest) Reset() {
+	*x = ListLessonsRequest{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TestResult) String() string {
+func (x *ListLessonsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TestResult) ProtoMessage() {}
+func (*ListLessonsRequest) ProtoMessage() {}
 
-func (x *TestResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[5]
+func (x *ListLessonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,62 +2441,87 @@ func (x *TestResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TestResult.ProtoReflect.Descriptor instead.
-func (*TestResult) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListLessonsRequest.ProtoReflect.Descriptor instead.
+func (*ListLessonsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *TestResult) GetPassed() bool {
+func (x *ListLessonsRequest) GetUserId() string {
 	if x != nil {
-		return x.Passed
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-func (x *TestResult) GetTestCaseDescription() string {
-	if x != nil {
-		return x.TestCaseDescription
-	}
-	return ""
+type ListLessonsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracks []*Track `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
 }
 
-func (x *TestResult) GetActualOutput() string {
+func (x *ListLessonsResponse) Reset() {
+	*x = ListLessonsResponse{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLessonsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLessonsResponse) ProtoMessage() {}
+
+func (x *ListLessonsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[28]
 	if x != nil {
-		return x.ActualOutput
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TestResult) GetExpectedOutput() string {
+// Deprecated: Use ListLessonsResponse.ProtoReflect.Descriptor instead.
+func (*ListLessonsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListLessonsResponse) GetTracks() []*Track {
 	if x != nil {
-		return x.ExpectedOutput
+		return x.Tracks
 	}
-	return ""
+	return nil
 }
 
-type ProgressRequest struct {
+type Track struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name    string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lessons []*LessonSummary `protobuf:"bytes,2,rep,name=lessons,proto3" json:"lessons,omitempty"`
 }
 
-func (x *ProgressRequest) Reset() {
-	*x = ProgressRequest{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[6]
+func (x *Track) Reset() {
+	*x = Track{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProgressRequest) String() string {
+func (x *Track) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProgressRequest) ProtoMessage() {}
+func (*Track) ProtoMessage() {}
 
-func (x *ProgressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[6]
+func (x *Track) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,43 +2532,51 @@ func (x *ProgressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProgressRequest.ProtoReflect.Descriptor instead.
-func (*ProgressRequest) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Track.ProtoReflect.Descriptor instead.
+func (*Track) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *ProgressRequest) GetUserId() string {
+func (x *Track) GetName() string {
 	if x != nil {
-		return x.UserId
+		return x.Name
 	}
 	return ""
 }
 
-type ProgressResponse struct {
+func (x *Track) GetLessons() []*LessonSummary {
+	if x != nil {
+		return x.Lessons
+	}
+	return nil
+}
+
+type LessonSummary struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CurrentLesson        int32   `protobuf:"varint,1,opt,name=current_lesson,json=currentLesson,proto3" json:"current_lesson,omitempty"`
-	CompletedLessons     []int32 `protobuf:"varint,2,rep,packed,name=completed_lessons,json=completedLessons,proto3" json:"completed_lessons,omitempty"`
-	CompletionPercentage float32 `protobuf:"fixed32,3,opt,name=completion_percentage,json=completionPercentage,proto3" json:"completion_percentage,omitempty"`
+	LessonId      int32        `protobuf:"varint,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	Title         string       `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Prerequisites []int32      `protobuf:"varint,3,rep,packed,name=prerequisites,proto3" json:"prerequisites,omitempty"`
+	Status        LessonStatus `protobuf:"varint,4,opt,name=status,proto3,enum=clearning.LessonStatus" json:"status,omitempty"`
 }
 
-func (x *ProgressResponse) Reset() {
-	*x = ProgressResponse{}
-	mi := &file_proto_v1_clearning_proto_msgTypes[7]
+func (x *LessonSummary) Reset() {
+	*x = LessonSummary{}
+	mi := &file_proto_v1_clearning_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProgressResponse) String() string {
+func (x *LessonSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProgressResponse) ProtoMessage() {}
+func (*LessonSummary) ProtoMessage() {}
 
-func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_v1_clearning_proto_msgTypes[7]
+func (x *LessonSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_clearning_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,30 +2587,37 @@ func (x *ProgressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProgressResponse.ProtoReflect.Descriptor instead.
-func (*ProgressResponse) Descriptor() ([]byte, []int) {
-	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use LessonSummary.ProtoReflect.Descriptor instead.
+func (*LessonSummary) Descriptor() ([]byte, []int) {
+	return file_proto_v1_clearning_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ProgressResponse) GetCurrentLesson() int32 {
+func (x *LessonSummary) GetLessonId() int32 {
 	if x != nil {
-		return x.CurrentLesson
+		return x.LessonId
 	}
 	return 0
 }
 
-func (x *ProgressResponse) GetCompletedLessons() []int32 {
+func (x *LessonSummary) GetTitle() string {
 	if x != nil {
-		return x.CompletedLessons
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LessonSummary) GetPrerequisites() []int32 {
+	if x != nil {
+		return x.Prerequisites
 	}
 	return nil
 }
 
-func (x *ProgressResponse) GetCompletionPercentage() float32 {
+func (x *LessonSummary) GetStatus() LessonStatus {
 	if x != nil {
-		return x.CompletionPercentage
+		return x.Status
 	}
-	return 0
+	return LessonStatus_LESSON_STATUS_UNSPECIFIED
 }
 
 var File_proto_v1_clearning_proto protoreflect.FileDescriptor
@@ -513,88 +2625,459 @@ var File_proto_v1_clearning_proto protoreflect.FileDescriptor
 var file_proto_v1_clearning_proto_rawDesc = []byte{
 	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6c, 0x65, 0x61, 0x72,
 	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x63, 0x6c, 0x65, 0x61,
-	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x2c, 0x0a, 0x0d, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52,
+	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x0d, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc9, 0x04, 0x0a, 0x0e, 0x4c,
+	0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x13, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x12, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63,
+	0x61, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x52,
+	0x09, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x05, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x3c, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x5f, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x70, 0x61, 0x73, 0x73,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x69, 0x6e,
+	0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x68,
+	0x69, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x69, 0x6e, 0x74,
+	0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b,
+	0x68, 0x69, 0x6e, 0x74, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x68,
+	0x61, 0x73, 0x5f, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36,
+	0x0a, 0x17, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x15, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x7f, 0x0a, 0x0b, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x74, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x74, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x62,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x62, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x73, 0x22, 0xde, 0x05, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74,
+	0x43, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x72, 0x67, 0x73, 0x12, 0x2e, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03,
+	0x65, 0x6e, 0x76, 0x12, 0x34, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x12, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x45, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0f,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x88, 0x01, 0x01, 0x12, 0x4d, 0x0a, 0x0e, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x72,
+	0x6e, 0x65, 0x73, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x72, 0x6e,
+	0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x38, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x45,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x15, 0x0a,
+	0x13, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x22, 0xce, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x64,
+	0x65, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61, 0x6e, 0x69, 0x74,
+	0x69, 0x7a, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x05,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x3a, 0x0a, 0x0a, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xe9, 0x03, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x69, 0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x12, 0x37,
+	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x69,
+	0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63,
+	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x68,
+	0x69, 0x6e, 0x74, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x68, 0x69, 0x6e, 0x74, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x69,
+	0x6e, 0x74, 0x5f, 0x64, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0d, 0x68, 0x69, 0x6e, 0x74, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x82, 0x02, 0x0a, 0x0a, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x39, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x06,
+	0x66, 0x69, 0x78, 0x69, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x46, 0x69, 0x78, 0x49, 0x74, 0x52, 0x06,
+	0x66, 0x69, 0x78, 0x69, 0x74, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x05, 0x46, 0x69, 0x78, 0x49, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0xc3, 0x04, 0x0a, 0x0a, 0x54, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65, 0x64, 0x12,
+	0x32, 0x0a, 0x15, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75,
+	0x61, 0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61,
+	0x6c, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x72,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x4a, 0x0a, 0x12, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x72,
+	0x5f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x61, 0x6e, 0x69,
+	0x74, 0x69, 0x7a, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x11, 0x73, 0x61,
+	0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0xff,
+	0x01, 0x0a, 0x10, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53,
+	0x74, 0x61, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b,
+	0x22, 0x50, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x69,
+	0x6e, 0x65, 0x22, 0x6b, 0x0a, 0x0f, 0x41, 0x73, 0x73, 0x65, 0x72, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73, 0x73, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x8e, 0x03, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x44, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x69,
+	0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x10, 0x63, 0x6f, 0x6d,
+	0x70, 0x69, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x12, 0x3b, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12,
+	0x3e, 0x0a, 0x0d, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12,
+	0x39, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x48, 0x00, 0x52,
+	0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x22, 0x24, 0x0a, 0x06, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0xb0, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6d,
+	0x70, 0x69, 0x6c, 0x65, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x37,
+	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x5b, 0x0a, 0x0b, 0x54,
+	0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x53, 0x0a, 0x0c, 0x54, 0x65, 0x73, 0x74,
+	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x2c, 0x0a,
+	0x11, 0x4e, 0x65, 0x78, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x7a, 0x0a, 0x12, 0x4e,
+	0x65, 0x78, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x63, 0x75, 0x72, 0x72, 0x69, 0x63, 0x75, 0x6c,
+	0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x13, 0x63, 0x75, 0x72, 0x72, 0x69, 0x63, 0x75, 0x6c, 0x75, 0x6d, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x43, 0x0a, 0x0b, 0x48, 0x69, 0x6e, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f,
-	0x6e, 0x49, 0x64, 0x22, 0xed, 0x01, 0x0a, 0x0e, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e,
+	0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x84, 0x01, 0x0a,
+	0x0c, 0x48, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x68, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x68, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x48,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x68, 0x69, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x68, 0x69, 0x6e, 0x74, 0x50, 0x65, 0x6e, 0x61,
+	0x6c, 0x74, 0x79, 0x22, 0x47, 0x0a, 0x0f, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f,
-	0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x65,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2f,
-	0x0a, 0x13, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x6c, 0x65, 0x61,
-	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12,
-	0x32, 0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
-	0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x52, 0x09, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61,
-	0x73, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
-	0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0x41, 0x0a, 0x0e, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
-	0x6f, 0x64, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73,
-	0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73,
-	0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6c,
-	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x0b, 0x74, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
-	0x1a, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
-	0x61, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0a, 0x63, 0x61, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x65, 0x64, 0x22, 0xa6, 0x01, 0x0a,
-	0x0a, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x61, 0x73, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x73,
-	0x73, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x73, 0x65,
-	0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x13, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x44, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x75, 0x61,
-	0x6c, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f,
-	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x2a, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x22, 0x9b, 0x01, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x5f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
-	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x12, 0x2b, 0x0a,
-	0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x65, 0x73, 0x73, 0x6f,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
-	0x74, 0x65, 0x64, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x15, 0x63, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
-	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x32,
-	0xeb, 0x01, 0x0a, 0x0f, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e,
-	0x12, 0x18, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x65, 0x73,
-	0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x65,
-	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e,
-	0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x56,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50,
-	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a,
-	0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x66, 0x73, 0x68,
-	0x69, 0x6e, 0x2d, 0x64, 0x65, 0x72, 0x69, 0x76, 0x2f, 0x63, 0x2d, 0x6c, 0x65, 0x61, 0x72, 0x6e,
-	0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x6e, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x6d, 0x0a, 0x10,
+	0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22, 0x2a, 0x0a, 0x0f, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x9b, 0x01, 0x0a, 0x10, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x73,
+	0x73, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x5f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x10,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73,
+	0x12, 0x33, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x14, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0x2d, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x73,
+	0x73, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x3f, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x73, 0x73,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x52, 0x06, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x73, 0x22, 0x4f, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x6c,
+	0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x0d, 0x4c, 0x65, 0x73, 0x73, 0x6f,
+	0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x73, 0x73,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x73,
+	0x73, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x70,
+	0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65,
+	0x73, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x65,
+	0x73, 0x73, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x2a, 0x97, 0x01, 0x0a, 0x12, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x1f, 0x44, 0x49, 0x41,
+	0x47, 0x4e, 0x4f, 0x53, 0x54, 0x49, 0x43, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d,
+	0x0a, 0x19, 0x44, 0x49, 0x41, 0x47, 0x4e, 0x4f, 0x53, 0x54, 0x49, 0x43, 0x5f, 0x53, 0x45, 0x56,
+	0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x1f, 0x0a,
+	0x1b, 0x44, 0x49, 0x41, 0x47, 0x4e, 0x4f, 0x53, 0x54, 0x49, 0x43, 0x5f, 0x53, 0x45, 0x56, 0x45,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x1c,
+	0x0a, 0x18, 0x44, 0x49, 0x41, 0x47, 0x4e, 0x4f, 0x53, 0x54, 0x49, 0x43, 0x5f, 0x53, 0x45, 0x56,
+	0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x10, 0x03, 0x2a, 0xb4, 0x02, 0x0a,
+	0x0a, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17, 0x54,
+	0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x45, 0x53, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x1c, 0x0a, 0x18, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x57, 0x52, 0x4f, 0x4e, 0x47, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x1d,
+	0x0a, 0x19, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x55,
+	0x4e, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x12, 0x23, 0x0a,
+	0x1f, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x54, 0x49, 0x4d,
+	0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x04, 0x12, 0x25, 0x0a, 0x21, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x4d, 0x45, 0x4d, 0x4f, 0x52, 0x59, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x45,
+	0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x05, 0x12, 0x20, 0x0a, 0x1c, 0x54, 0x45, 0x53,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x45, 0x44, 0x5f,
+	0x42, 0x59, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x4c, 0x10, 0x06, 0x12, 0x25, 0x0a, 0x21, 0x54,
+	0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55,
+	0x54, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44,
+	0x10, 0x07, 0x12, 0x1f, 0x0a, 0x1b, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x53, 0x41, 0x4e, 0x49, 0x54, 0x49, 0x5a, 0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x08, 0x2a, 0x81, 0x01, 0x0a, 0x0c, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x19, 0x4c, 0x45, 0x53, 0x53, 0x4f, 0x4e, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4c, 0x45, 0x53, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a,
+	0x17, 0x4c, 0x45, 0x53, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41,
+	0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x45,
+	0x53, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x32, 0x8d, 0x07, 0x0a, 0x0f, 0x4c, 0x65, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x61, 0x0a, 0x09, 0x47,
+	0x65, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4c,
+	0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x73, 0x73, 0x6f,
+	0x6e, 0x73, 0x2f, 0x7b, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x12, 0x75,
+	0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x19,
+	0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25,
+	0x3a, 0x01, 0x2a, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73,
+	0x2f, 0x7b, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x80, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x19, 0x2e, 0x63,
+	0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x1a, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0x31, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x3a, 0x01, 0x2a, 0x22, 0x26,
+	0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x6c, 0x65, 0x73,
+	0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x3a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30, 0x01, 0x12, 0x6c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x61, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65,
+	0x73, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x76,
+	0x31, 0x2f, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x75, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x4e, 0x65, 0x78, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x4c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x12,
+	0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x6e, 0x65, 0x78, 0x74, 0x2d, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e,
+	0x12, 0x63, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x63, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x48, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e,
+	0x48, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x27, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x21, 0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x73,
+	0x73, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x7d,
+	0x3a, 0x68, 0x69, 0x6e, 0x74, 0x12, 0x70, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x2e, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x28, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x65, 0x73, 0x73, 0x6f,
+	0x6e, 0x73, 0x2f, 0x7b, 0x6c, 0x65, 0x73, 0x73, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x73,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x66, 0x73, 0x68, 0x69, 0x6e, 0x2d, 0x64, 0x65, 0x72,
+	0x69, 0x76, 0x2f, 0x63, 0x2d, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -609,31 +3092,95 @@ func file_proto_v1_clearning_proto_rawDescGZIP() []byte {
 	return file_proto_v1_clearning_proto_rawDescData
 }
 
-var file_proto_v1_clearning_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_v1_clearning_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_v1_clearning_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_proto_v1_clearning_proto_goTypes = []any{
-	(*LessonRequest)(nil),      // 0: clearning.LessonRequest
-	(*LessonResponse)(nil),     // 1: clearning.LessonResponse
-	(*TestCase)(nil),           // 2: clearning.TestCase
-	(*CodeSubmission)(nil),     // 3: clearning.CodeSubmission
-	(*ValidationResponse)(nil), // 4: clearning.ValidationResponse
-	(*TestResult)(nil),         // 5: clearning.TestResult
-	(*ProgressRequest)(nil),    // 6: clearning.ProgressRequest
-	(*ProgressResponse)(nil),   // 7: clearning.ProgressResponse
+	(DiagnosticSeverity)(0),     // 0: clearning.DiagnosticSeverity
+	(TestStatus)(0),             // 1: clearning.TestStatus
+	(LessonStatus)(0),           // 2: clearning.LessonStatus
+	(*LessonRequest)(nil),       // 3: clearning.LessonRequest
+	(*LessonResponse)(nil),      // 4: clearning.LessonResponse
+	(*BuildConfig)(nil),         // 5: clearning.BuildConfig
+	(*TestCase)(nil),            // 6: clearning.TestCase
+	(*CodeSubmission)(nil),      // 7: clearning.CodeSubmission
+	(*SourceFile)(nil),          // 8: clearning.SourceFile
+	(*ValidationResponse)(nil),  // 9: clearning.ValidationResponse
+	(*Diagnostic)(nil),          // 10: clearning.Diagnostic
+	(*FixIt)(nil),               // 11: clearning.FixIt
+	(*TestResult)(nil),          // 12: clearning.TestResult
+	(*SanitizerFinding)(nil),    // 13: clearning.SanitizerFinding
+	(*StackFrame)(nil),          // 14: clearning.StackFrame
+	(*AssertionResult)(nil),     // 15: clearning.AssertionResult
+	(*ValidationEvent)(nil),     // 16: clearning.ValidationEvent
+	(*Queued)(nil),              // 17: clearning.Queued
+	(*CompileStarted)(nil),      // 18: clearning.CompileStarted
+	(*CompileFinished)(nil),     // 19: clearning.CompileFinished
+	(*TestStarted)(nil),         // 20: clearning.TestStarted
+	(*TestFinished)(nil),        // 21: clearning.TestFinished
+	(*NextLessonRequest)(nil),   // 22: clearning.NextLessonRequest
+	(*NextLessonResponse)(nil),  // 23: clearning.NextLessonResponse
+	(*HintRequest)(nil),         // 24: clearning.HintRequest
+	(*HintResponse)(nil),        // 25: clearning.HintResponse
+	(*SolutionRequest)(nil),     // 26: clearning.SolutionRequest
+	(*SolutionResponse)(nil),    // 27: clearning.SolutionResponse
+	(*ProgressRequest)(nil),     // 28: clearning.ProgressRequest
+	(*ProgressResponse)(nil),    // 29: clearning.ProgressResponse
+	(*ListLessonsRequest)(nil),  // 30: clearning.ListLessonsRequest
+	(*ListLessonsResponse)(nil), // 31: clearning.ListLessonsResponse
+	(*Track)(nil),               // 32: clearning.Track
+	(*LessonSummary)(nil),       // 33: clearning.LessonSummary
+	nil,                         // 34: clearning.TestCase.EnvEntry
+	nil,                         // 35: clearning.TestCase.FilesEntry
+	nil,                         // 36: clearning.TestCase.ExpectedFilesEntry
 }
 var file_proto_v1_clearning_proto_depIdxs = []int32{
-	2, // 0: clearning.LessonResponse.test_cases:type_name -> clearning.TestCase
-	5, // 1: clearning.ValidationResponse.test_results:type_name -> clearning.TestResult
-	0, // 2: clearning.LearningService.GetLesson:input_type -> clearning.LessonRequest
-	3, // 3: clearning.LearningService.ValidateCode:input_type -> clearning.CodeSubmission
-	6, // 4: clearning.LearningService.GetProgress:input_type -> clearning.ProgressRequest
-	1, // 5: clearning.LearningService.GetLesson:output_type -> clearning.LessonResponse
-	4, // 6: clearning.LearningService.ValidateCode:output_type -> clearning.ValidationResponse
-	7, // 7: clearning.LearningService.GetProgress:output_type -> clearning.ProgressResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6,  // 0: clearning.LessonResponse.test_cases:type_name -> clearning.TestCase
+	5,  // 1: clearning.LessonResponse.build:type_name -> clearning.BuildConfig
+	8,  // 2: clearning.LessonResponse.provided_files:type_name -> clearning.SourceFile
+	34, // 3: clearning.TestCase.env:type_name -> clearning.TestCase.EnvEntry
+	35, // 4: clearning.TestCase.files:type_name -> clearning.TestCase.FilesEntry
+	36, // 5: clearning.TestCase.expected_files:type_name -> clearning.TestCase.ExpectedFilesEntry
+	8,  // 6: clearning.CodeSubmission.files:type_name -> clearning.SourceFile
+	12, // 7: clearning.ValidationResponse.test_results:type_name -> clearning.TestResult
+	10, // 8: clearning.ValidationResponse.diagnostics:type_name -> clearning.Diagnostic
+	0,  // 9: clearning.Diagnostic.severity:type_name -> clearning.DiagnosticSeverity
+	11, // 10: clearning.Diagnostic.fixits:type_name -> clearning.FixIt
+	1,  // 11: clearning.TestResult.status:type_name -> clearning.TestStatus
+	15, // 12: clearning.TestResult.assertions:type_name -> clearning.AssertionResult
+	13, // 13: clearning.TestResult.sanitizer_findings:type_name -> clearning.SanitizerFinding
+	14, // 14: clearning.SanitizerFinding.stack:type_name -> clearning.StackFrame
+	18, // 15: clearning.ValidationEvent.compile_started:type_name -> clearning.CompileStarted
+	19, // 16: clearning.ValidationEvent.compile_finished:type_name -> clearning.CompileFinished
+	20, // 17: clearning.ValidationEvent.test_started:type_name -> clearning.TestStarted
+	21, // 18: clearning.ValidationEvent.test_finished:type_name -> clearning.TestFinished
+	9,  // 19: clearning.ValidationEvent.summary:type_name -> clearning.ValidationResponse
+	17, // 20: clearning.ValidationEvent.queued:type_name -> clearning.Queued
+	10, // 21: clearning.CompileFinished.diagnostics:type_name -> clearning.Diagnostic
+	12, // 22: clearning.TestFinished.result:type_name -> clearning.TestResult
+	32, // 23: clearning.ListLessonsResponse.tracks:type_name -> clearning.Track
+	33, // 24: clearning.Track.lessons:type_name -> clearning.LessonSummary
+	2,  // 25: clearning.LessonSummary.status:type_name -> clearning.LessonStatus
+	3,  // 26: clearning.LearningService.GetLesson:input_type -> clearning.LessonRequest
+	7,  // 27: clearning.LearningService.ValidateCode:input_type -> clearning.CodeSubmission
+	7,  // 28: clearning.LearningService.ValidateCodeStream:input_type -> clearning.CodeSubmission
+	28, // 29: clearning.LearningService.GetProgress:input_type -> clearning.ProgressRequest
+	30, // 30: clearning.LearningService.ListLessons:input_type -> clearning.ListLessonsRequest
+	22, // 31: clearning.LearningService.GetNextLesson:input_type -> clearning.NextLessonRequest
+	24, // 32: clearning.LearningService.GetHint:input_type -> clearning.HintRequest
+	26, // 33: clearning.LearningService.GetSolution:input_type -> clearning.SolutionRequest
+	4,  // 34: clearning.LearningService.GetLesson:output_type -> clearning.LessonResponse
+	9,  // 35: clearning.LearningService.ValidateCode:output_type -> clearning.ValidationResponse
+	16, // 36: clearning.LearningService.ValidateCodeStream:output_type -> clearning.ValidationEvent
+	29, // 37: clearning.LearningService.GetProgress:output_type -> clearning.ProgressResponse
+	31, // 38: clearning.LearningService.ListLessons:output_type -> clearning.ListLessonsResponse
+	23, // 39: clearning.LearningService.GetNextLesson:output_type -> clearning.NextLessonResponse
+	25, // 40: clearning.LearningService.GetHint:output_type -> clearning.HintResponse
+	27, // 41: clearning.LearningService.GetSolution:output_type -> clearning.SolutionResponse
+	34, // [34:42] is the sub-list for method output_type
+	26, // [26:34] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_proto_v1_clearning_proto_init() }
@@ -641,18 +3188,28 @@ func file_proto_v1_clearning_proto_init() {
 	if File_proto_v1_clearning_proto != nil {
 		return
 	}
+	file_proto_v1_clearning_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_v1_clearning_proto_msgTypes[13].OneofWrappers = []any{
+		(*ValidationEvent_CompileStarted)(nil),
+		(*ValidationEvent_CompileFinished)(nil),
+		(*ValidationEvent_TestStarted)(nil),
+		(*ValidationEvent_TestFinished)(nil),
+		(*ValidationEvent_Summary)(nil),
+		(*ValidationEvent_Queued)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_v1_clearning_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      3,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_v1_clearning_proto_goTypes,
 		DependencyIndexes: file_proto_v1_clearning_proto_depIdxs,
+		EnumInfos:         file_proto_v1_clearning_proto_enumTypes,
 		MessageInfos:      file_proto_v1_clearning_proto_msgTypes,
 	}.Build()
 	File_proto_v1_clearning_proto = out.File
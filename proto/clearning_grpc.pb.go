@@ -19,9 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LearningService_GetLesson_FullMethodName    = "/clearning.LearningService/GetLesson"
-	LearningService_ValidateCode_FullMethodName = "/clearning.LearningService/ValidateCode"
-	LearningService_GetProgress_FullMethodName  = "/clearning.LearningService/GetProgress"
+	LearningService_GetLesson_FullMethodName          = "/clearning.LearningService/GetLesson"
+	LearningService_ValidateCode_FullMethodName       = "/clearning.LearningService/ValidateCode"
+	LearningService_ValidateCodeStream_FullMethodName = "/clearning.LearningService/ValidateCodeStream"
+	LearningService_GetProgress_FullMethodName        = "/clearning.LearningService/GetProgress"
+	LearningService_ListLessons_FullMethodName        = "/clearning.LearningService/ListLessons"
+	LearningService_GetNextLesson_FullMethodName      = "/clearning.LearningService/GetNextLesson"
+	LearningService_GetHint_FullMethodName            = "/clearning.LearningService/GetHint"
+	LearningService_GetSolution_FullMethodName        = "/clearning.LearningService/GetSolution"
 )
 
 // LearningServiceClient is the client API for LearningService service.
@@ -32,8 +37,24 @@ type LearningServiceClient interface {
 	GetLesson(ctx context.Context, in *LessonRequest, opts ...grpc.CallOption) (*LessonResponse, error)
 	// Validate user's code submission
 	ValidateCode(ctx context.Context, in *CodeSubmission, opts ...grpc.CallOption) (*ValidationResponse, error)
+	// Validate user's code submission, streaming progress as it is graded.
+	// The last event is always the summary.
+	ValidateCodeStream(ctx context.Context, in *CodeSubmission, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidationEvent], error)
 	// Get user's progress
 	GetProgress(ctx context.Context, in *ProgressRequest, opts ...grpc.CallOption) (*ProgressResponse, error)
+	// List all lessons grouped by track, in curriculum order
+	ListLessons(ctx context.Context, in *ListLessonsRequest, opts ...grpc.CallOption) (*ListLessonsResponse, error)
+	// Get the first lesson, in curriculum order, the user has not completed
+	// and may start
+	GetNextLesson(ctx context.Context, in *NextLessonRequest, opts ...grpc.CallOption) (*NextLessonResponse, error)
+	// Reveal the user's next hint for a lesson, along with those revealed
+	// before. Each hint revealed lowers the score of later submissions by the
+	// lesson's hint penalty.
+	GetHint(ctx context.Context, in *HintRequest, opts ...grpc.CallOption) (*HintResponse, error)
+	// Get a lesson's reference solution. It is only returned once the user
+	// has completed the lesson, or failed it as many times as the lesson
+	// allows before revealing it.
+	GetSolution(ctx context.Context, in *SolutionRequest, opts ...grpc.CallOption) (*SolutionResponse, error)
 }
 
 type learningServiceClient struct {
@@ -64,6 +85,25 @@ func (c *learningServiceClient) ValidateCode(ctx context.Context, in *CodeSubmis
 	return out, nil
 }
 
+func (c *learningServiceClient) ValidateCodeStream(ctx context.Context, in *CodeSubmission, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ValidationEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LearningService_ServiceDesc.Streams[0], LearningService_ValidateCodeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CodeSubmission, ValidationEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_ValidateCodeStreamClient = grpc.ServerStreamingClient[ValidationEvent]
+
 func (c *learningServiceClient) GetProgress(ctx context.Context, in *ProgressRequest, opts ...grpc.CallOption) (*ProgressResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ProgressResponse)
@@ -74,6 +114,46 @@ func (c *learningServiceClient) GetProgress(ctx context.Context, in *ProgressReq
 	return out, nil
 }
 
+func (c *learningServiceClient) ListLessons(ctx context.Context, in *ListLessonsRequest, opts ...grpc.CallOption) (*ListLessonsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLessonsResponse)
+	err := c.cc.Invoke(ctx, LearningService_ListLessons_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetNextLesson(ctx context.Context, in *NextLessonRequest, opts ...grpc.CallOption) (*NextLessonResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextLessonResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetNextLesson_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetHint(ctx context.Context, in *HintRequest, opts ...grpc.CallOption) (*HintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HintResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetHint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *learningServiceClient) GetSolution(ctx context.Context, in *SolutionRequest, opts ...grpc.CallOption) (*SolutionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SolutionResponse)
+	err := c.cc.Invoke(ctx, LearningService_GetSolution_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LearningServiceServer is the server API for LearningService service.
 // All implementations must embed UnimplementedLearningServiceServer
 // for forward compatibility.
@@ -82,8 +162,24 @@ type LearningServiceServer interface {
 	GetLesson(context.Context, *LessonRequest) (*LessonResponse, error)
 	// Validate user's code submission
 	ValidateCode(context.Context, *CodeSubmission) (*ValidationResponse, error)
+	// Validate user's code submission, streaming progress as it is graded.
+	// The last event is always the summary.
+	ValidateCodeStream(*CodeSubmission, grpc.ServerStreamingServer[ValidationEvent]) error
 	// Get user's progress
 	GetProgress(context.Context, *ProgressRequest) (*ProgressResponse, error)
+	// List all lessons grouped by track, in curriculum order
+	ListLessons(context.Context, *ListLessonsRequest) (*ListLessonsResponse, error)
+	// Get the first lesson, in curriculum order, the user has not completed
+	// and may start
+	GetNextLesson(context.Context, *NextLessonRequest) (*NextLessonResponse, error)
+	// Reveal the user's next hint for a lesson, along with those revealed
+	// before. Each hint revealed lowers the score of later submissions by the
+	// lesson's hint penalty.
+	GetHint(context.Context, *HintRequest) (*HintResponse, error)
+	// Get a lesson's reference solution. It is only returned once the user
+	// has completed the lesson, or failed it as many times as the lesson
+	// allows before revealing it.
+	GetSolution(context.Context, *SolutionRequest) (*SolutionResponse, error)
 	mustEmbedUnimplementedLearningServiceServer()
 }
 
@@ -100,9 +196,24 @@ func (UnimplementedLearningServiceServer) GetLesson(context.Context, *LessonRequ
 func (UnimplementedLearningServiceServer) ValidateCode(context.Context, *CodeSubmission) (*ValidationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateCode not implemented")
 }
+func (UnimplementedLearningServiceServer) ValidateCodeStream(*CodeSubmission, grpc.ServerStreamingServer[ValidationEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method ValidateCodeStream not implemented")
+}
 func (UnimplementedLearningServiceServer) GetProgress(context.Context, *ProgressRequest) (*ProgressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProgress not implemented")
 }
+func (UnimplementedLearningServiceServer) ListLessons(context.Context, *ListLessonsRequest) (*ListLessonsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLessons not implemented")
+}
+func (UnimplementedLearningServiceServer) GetNextLesson(context.Context, *NextLessonRequest) (*NextLessonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNextLesson not implemented")
+}
+func (UnimplementedLearningServiceServer) GetHint(context.Context, *HintRequest) (*HintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHint not implemented")
+}
+func (UnimplementedLearningServiceServer) GetSolution(context.Context, *SolutionRequest) (*SolutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSolution not implemented")
+}
 func (UnimplementedLearningServiceServer) mustEmbedUnimplementedLearningServiceServer() {}
 func (UnimplementedLearningServiceServer) testEmbeddedByValue()                         {}
 
@@ -160,6 +271,17 @@ func _LearningService_ValidateCode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LearningService_ValidateCodeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CodeSubmission)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LearningServiceServer).ValidateCodeStream(m, &grpc.GenericServerStream[CodeSubmission, ValidationEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LearningService_ValidateCodeStreamServer = grpc.ServerStreamingServer[ValidationEvent]
+
 func _LearningService_GetProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProgressRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +300,78 @@ func _LearningService_GetProgress_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LearningService_ListLessons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLessonsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).ListLessons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_ListLessons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).ListLessons(ctx, req.(*ListLessonsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetNextLesson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextLessonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetNextLesson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetNextLesson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetNextLesson(ctx, req.(*NextLessonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetHint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetHint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetHint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetHint(ctx, req.(*HintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LearningService_GetSolution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LearningServiceServer).GetSolution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LearningService_GetSolution_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LearningServiceServer).GetSolution(ctx, req.(*SolutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LearningService_ServiceDesc is the grpc.ServiceDesc for LearningService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,7 +391,29 @@ var LearningService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProgress",
 			Handler:    _LearningService_GetProgress_Handler,
 		},
+		{
+			MethodName: "ListLessons",
+			Handler:    _LearningService_ListLessons_Handler,
+		},
+		{
+			MethodName: "GetNextLesson",
+			Handler:    _LearningService_GetNextLesson_Handler,
+		},
+		{
+			MethodName: "GetHint",
+			Handler:    _LearningService_GetHint_Handler,
+		},
+		{
+			MethodName: "GetSolution",
+			Handler:    _LearningService_GetSolution_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValidateCodeStream",
+			Handler:       _LearningService_ValidateCodeStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/v1/clearning.proto",
 }